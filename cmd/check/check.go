@@ -9,16 +9,24 @@ import (
 	"github.com/mertbahardogan/escope/internal/elastic"
 	"github.com/mertbahardogan/escope/internal/interfaces"
 	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
+	"github.com/mertbahardogan/escope/internal/rules"
 	"github.com/mertbahardogan/escope/internal/services"
 	"github.com/mertbahardogan/escope/internal/ui"
 	"github.com/mertbahardogan/escope/internal/util"
 	"github.com/spf13/cobra"
+	"os"
 	"time"
 )
 
 var (
-	duration string
-	interval string
+	duration   string
+	interval   string
+	failOn     string
+	rulesFile  string
+	recordFile string
+
+	sampleThreadPools bool
 )
 
 var checkCmd = &cobra.Command{
@@ -26,21 +34,78 @@ var checkCmd = &cobra.Command{
 	Short:         "Check cluster health metrics",
 	Long:          `Check various aspects of your Elasticsearch cluster health and performance`,
 	SilenceErrors: true,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !validFailOn() {
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+		if recordFile != "" && duration == "" {
+			fmt.Println("--record requires --duration")
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+
+		ruleSet, ok := loadRuleSet(resolveRulesPath(""))
+		if !ok {
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+
 		client := elastic.NewClientWrapper(connection.GetClient())
 		checkService := services.NewCheckService(client)
 		formatter := ui.NewCheckFormatter()
 
+		var summary *models.CheckSummary
 		if duration != "" {
-			runContinuousCheck(context.Background(), client, checkService, formatter)
-			return
+			summary = runContinuousCheck(context.Background(), client, checkService, formatter, ruleSet)
+		} else if report := runSingleCheck(context.Background(), checkService, formatter, ruleSet); report.ClusterHealth != nil {
+			summary = report.Summary
 		}
 
-		runSingleCheck(context.Background(), checkService, formatter)
+		if code := failOnExitCode(summary, failOn); code != 0 {
+			return &core.ExitError{Code: code}
+		}
+		return nil
 	},
 }
 
-func runSingleCheck(ctx context.Context, checkService services.CheckService, formatter *ui.CheckFormatter) {
+func runSingleCheck(ctx context.Context, checkService services.CheckService, formatter *ui.CheckFormatter, ruleSet *rules.RuleSet) *models.CheckReport {
+	report := collectCheckReport(ctx, checkService, ruleSet)
+	report.Summary = formatter.Summarize(report)
+
+	if core.OutputFormat().IsStructured() {
+		core.WriteOutput(output.KindCheck, report)
+		return report
+	}
+
+	fmt.Print(formatter.FormatCheckReport(report))
+	return report
+}
+
+func validFailOn() bool {
+	if failOn != "" && failOn != constants.FailOnWarning && failOn != constants.FailOnCritical {
+		fmt.Printf("Invalid --fail-on value %q. Valid values: %s, %s\n", failOn, constants.FailOnWarning, constants.FailOnCritical)
+		return false
+	}
+	return true
+}
+
+// failOnExitCode maps the worst finding to an exit code; findings below the --fail-on level exit 0.
+// A nil summary means the check could not complete.
+func failOnExitCode(summary *models.CheckSummary, level string) int {
+	if level == "" {
+		return 0
+	}
+	if summary == nil {
+		return constants.ExitCodeCheckIncomplete
+	}
+	if summary.Critical > 0 {
+		return constants.ExitCodeCritical
+	}
+	if summary.Warning > 0 && level == constants.FailOnWarning {
+		return constants.ExitCodeWarning
+	}
+	return 0
+}
+
+func collectCheckReport(ctx context.Context, checkService services.CheckService, ruleSet *rules.RuleSet) *models.CheckReport {
 	clusterHealth, err := util.ExecuteWithTimeout(func() (*models.ClusterInfo, error) {
 		return checkService.GetClusterHealthCheck(ctx)
 	})
@@ -60,6 +125,10 @@ func runSingleCheck(ctx context.Context, checkService services.CheckService, for
 		return checkService.GetShardWarningsCheck(ctx)
 	})
 	util.HandleServiceError(err, "Shard warnings check")
+	// Allocation explain calls get a timeout of their own; see ExplainUnassignedShards
+	explainCtx, cancelExplain := util.CreateTimeoutContext()
+	checkService.ExplainUnassignedShards(explainCtx, shardWarnings)
+	cancelExplain()
 
 	indexHealths, err := util.ExecuteWithTimeout(func() ([]models.IndexHealth, error) {
 		return checkService.GetIndexHealthCheck(ctx)
@@ -91,33 +160,57 @@ func runSingleCheck(ctx context.Context, checkService services.CheckService, for
 	})
 	util.HandleServiceError(err, "Scale warnings check")
 
+	// Sampling waits between two snapshots, so it only runs when asked for
+	var threadPoolWarnings *models.ThreadPoolWarnings
+	if sampleThreadPools {
+		threadPoolWarnings, err = util.ExecuteWithTimeout(func() (*models.ThreadPoolWarnings, error) {
+			return checkService.GetThreadPoolWarningsCheck(ctx)
+		})
+		util.HandleServiceError(err, "Thread pool check")
+	}
+
+	breakerWarnings, err := util.ExecuteWithTimeout(func() (*models.BreakerWarnings, error) {
+		return checkService.GetBreakerWarningsCheck(ctx)
+	})
+	util.HandleServiceError(err, "Circuit breaker check")
+
 	indicesWithoutAlias, err := util.ExecuteWithTimeout(func() ([]string, error) {
 		return checkService.GetIndicesWithoutAliasInfo(ctx)
 	})
 	util.HandleServiceError(err, "Indices without alias check")
 
-	output := formatter.FormatCheckReport(
-		clusterHealth,
-		nodeHealths,
-		shardHealth,
-		shardWarnings,
-		indexHealths,
-		resourceUsage,
-		performance,
-		nodeBreakdown,
-		segmentWarnings,
-		scaleWarnings,
-		indicesWithoutAlias,
-	)
-	fmt.Print(output)
+	var ruleFindings []models.RuleFinding
+	if ruleSet != nil {
+		ruleFindings, err = util.ExecuteWithTimeout(func() ([]models.RuleFinding, error) {
+			return checkService.GetRuleFindings(ctx, ruleSet)
+		})
+		util.HandleServiceError(err, "Rules check")
+	}
+
+	return &models.CheckReport{
+		ClusterHealth:       clusterHealth,
+		NodeHealths:         nodeHealths,
+		ShardHealth:         shardHealth,
+		ShardWarnings:       shardWarnings,
+		IndexHealths:        indexHealths,
+		ResourceUsage:       resourceUsage,
+		Performance:         performance,
+		NodeBreakdown:       nodeBreakdown,
+		SegmentWarnings:     segmentWarnings,
+		ScaleWarnings:       scaleWarnings,
+		ThreadPoolWarnings:  threadPoolWarnings,
+		BreakerWarnings:     breakerWarnings,
+		IndicesWithoutAlias: indicesWithoutAlias,
+		RuleFindings:        ruleFindings,
+	}
 }
 
-func runContinuousCheck(ctx context.Context, client interfaces.ElasticClient, checkService services.CheckService, formatter *ui.CheckFormatter) {
+func runContinuousCheck(ctx context.Context, client interfaces.ElasticClient, checkService services.CheckService, formatter *ui.CheckFormatter, ruleSet *rules.RuleSet) *models.CheckSummary {
 	durationTime, err := time.ParseDuration(duration)
 	if err != nil {
 		fmt.Printf("Invalid duration format: %v\n", err)
 		fmt.Println("Valid formats: 1m, 5m, 1h, etc.")
-		return
+		return nil
 	}
 
 	intervalTime := time.Duration(constants.DefaultInterval) * time.Second
@@ -126,22 +219,74 @@ func runContinuousCheck(ctx context.Context, client interfaces.ElasticClient, ch
 		if err != nil {
 			fmt.Printf("Invalid interval format: %v\n", err)
 			fmt.Println("Valid formats: 5s, 10s, 1m, etc.")
-			return
+			return nil
 		}
 	}
 
 	monitoringService := services.NewMonitoringService(client)
+	if core.OutputFormat().IsStructured() {
+		monitoringService.WithProgressOutput(os.Stderr)
+	}
+	if recordFile != "" {
+		f, err := os.OpenFile(recordFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Cannot open record file: %v\n", err)
+			return nil
+		}
+		defer f.Close()
+		monitoringService.WithRecorder(f)
+	}
 
 	result, err := monitoringService.MonitorCluster(ctx, durationTime, intervalTime)
 	if err != nil {
-		fmt.Printf("Monitoring failed: %v\n", err)
-		return
+		fmt.Fprintf(os.Stderr, "Monitoring failed: %v\n", err)
+		return nil
+	}
+
+	if result.SampleCount == 0 {
+		fmt.Fprintln(os.Stderr, "No samples collected during monitoring period.")
+		return nil
+	}
+
+	if ruleSet != nil {
+		appendRuleIssues(ctx, checkService, ruleSet, result)
+	}
+
+	return renderTrendReport(result, formatter)
+}
+
+// renderTrendReport prints the trend analysis in the selected output format and returns its summary
+func renderTrendReport(result *models.MonitoringResult, formatter *ui.CheckFormatter) *models.CheckSummary {
+	result.Summary = formatter.SummarizeTrend(result)
+
+	if core.OutputFormat().IsStructured() {
+		core.WriteOutput(output.KindCheckTrend, result)
+		return result.Summary
 	}
 
-	if result.SampleCount > 0 {
-		runSingleCheck(ctx, checkService, formatter)
-	} else {
-		fmt.Println("No samples collected during monitoring period.")
+	fmt.Print(formatter.FormatTrendReport(result))
+	return result.Summary
+}
+
+// appendRuleIssues evaluates the rules once at the end of the window and reports matches as trend issues
+func appendRuleIssues(ctx context.Context, checkService services.CheckService, ruleSet *rules.RuleSet, result *models.MonitoringResult) {
+	findings, err := util.ExecuteWithTimeout(func() ([]models.RuleFinding, error) {
+		return checkService.GetRuleFindings(ctx, ruleSet)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Rules check failed: %v\n", err)
+		return
+	}
+	now := time.Now()
+	for _, finding := range findings {
+		result.Issues = append(result.Issues, models.MonitoringIssue{
+			Type:        "Rule " + finding.Rule,
+			Severity:    finding.Severity,
+			Description: finding.Message,
+			Occurrences: 1,
+			FirstSeen:   now,
+			LastSeen:    now,
+		})
 	}
 }
 
@@ -149,6 +294,14 @@ func init() {
 	checkCmd.Flags().StringVarP(&duration, "duration", "d", "", "Duration for continuous monitoring (e.g., 1m, 5m, 1h)")
 	checkCmd.Flags().StringVarP(&interval, "interval", "i", "",
 		"Sampling interval for continuous monitoring (e.g., 5s, 10s, 1m, default: 2s)")
+	checkCmd.PersistentFlags().StringVar(&rulesFile, "rules", "",
+		"Rules file with extra checks (default: rules_file saved with the active host)")
+	checkCmd.Flags().StringVar(&recordFile, "record", "",
+		"Append every monitoring sample to this NDJSON file (requires --duration); analyze later with 'escope check replay'")
+	checkCmd.Flags().BoolVar(&sampleThreadPools, "sample-thread-pools", false,
+		fmt.Sprintf("Sample the thread pools twice, %ds apart, and warn about pools whose rejections grow", constants.ThreadPoolCheckSampleSeconds))
+	checkCmd.Flags().StringVar(&failOn, "fail-on", "",
+		"Exit non-zero when findings reach this severity: warning (exit 2, or 3 if critical) or critical (exit 3)")
 
 	core.RootCmd.AddCommand(checkCmd)
 }
@@ -0,0 +1,53 @@
+package check
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var replayCmd = &cobra.Command{
+	Use:         "replay <samples.ndjson>",
+	Short:       "Analyze samples recorded with 'escope check --duration --record' without a cluster connection",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{core.SkipConnectionAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !validFailOn() {
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			fmt.Printf("Cannot open samples file: %v\n", err)
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+		defer f.Close()
+
+		result, err := services.NewMonitoringService(nil).Replay(f)
+		if err != nil {
+			fmt.Printf("Replay failed: %v\n", err)
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+		if result.SampleCount == 0 {
+			fmt.Println("No samples found in file.")
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+
+		summary := renderTrendReport(result, ui.NewCheckFormatter())
+		if code := failOnExitCode(summary, failOn); code != 0 {
+			return &core.ExitError{Code: code}
+		}
+		return nil
+	},
+}
+
+func init() {
+	replayCmd.Flags().StringVar(&failOn, "fail-on", "",
+		"Exit non-zero when findings reach this severity: warning (exit 2, or 3 if critical) or critical (exit 3)")
+	checkCmd.AddCommand(replayCmd)
+}
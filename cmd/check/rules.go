@@ -0,0 +1,109 @@
+package check
+
+import (
+	"fmt"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/mertbahardogan/escope/internal/config"
+	"github.com/mertbahardogan/escope/internal/connection"
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/rules"
+	"github.com/spf13/cobra"
+)
+
+var rulesCmd = &cobra.Command{
+	Use:         "rules",
+	Short:       "Manage check rules files",
+	Annotations: map[string]string{core.SkipConnectionAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var rulesValidateCmd = &cobra.Command{
+	Use:         "validate [file]",
+	Short:       "Lint a check rules file without contacting the cluster",
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{core.SkipConnectionAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var path string
+		if len(args) > 0 {
+			path = args[0]
+		}
+		path = resolveRulesPath(path)
+		if path == "" {
+			path = activeHostRulesFile()
+		}
+		if path == "" {
+			fmt.Println("No rules file given. Pass a file, use --rules, or save one with 'escope config --alias <name> --rules <file>'.")
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+
+		ruleSet, err := rules.Load(path)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+
+		errs := ruleSet.Validate()
+		if len(errs) > 0 {
+			fmt.Printf("%s: %d problem(s) found\n", path, len(errs))
+			for _, e := range errs {
+				fmt.Printf("  - %v\n", e)
+			}
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+
+		fmt.Printf("%s: %d rule(s) OK\n", path, len(ruleSet.Rules))
+		return nil
+	},
+}
+
+// resolveRulesPath picks the rules file in priority order: argument, --rules, the connected host's config
+func resolveRulesPath(path string) string {
+	if path != "" {
+		return path
+	}
+	if rulesFile != "" {
+		return rulesFile
+	}
+	return connection.CurrentRulesFile()
+}
+
+// activeHostRulesFile reads the rules file of the active host for offline commands
+func activeHostRulesFile() string {
+	activeHost, err := config.GetActiveHost()
+	if err != nil || activeHost == "" {
+		return ""
+	}
+	hostCfg, err := config.LoadHost(activeHost)
+	if err != nil {
+		return ""
+	}
+	return hostCfg.RulesFile
+}
+
+// loadRuleSet loads and lints the rules file; an empty path means no rules and is not an error
+func loadRuleSet(path string) (*rules.RuleSet, bool) {
+	if path == "" {
+		return nil, true
+	}
+	ruleSet, err := rules.Load(path)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return nil, false
+	}
+	if errs := ruleSet.Validate(); len(errs) > 0 {
+		fmt.Printf("Error: rules file %s has %d problem(s):\n", path, len(errs))
+		for _, e := range errs {
+			fmt.Printf("  - %v\n", e)
+		}
+		return nil, false
+	}
+	return ruleSet, true
+}
+
+func init() {
+	rulesCmd.AddCommand(rulesValidateCmd)
+	checkCmd.AddCommand(rulesCmd)
+}
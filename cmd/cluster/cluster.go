@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mertbahardogan/escope/cmd/core"
 	"github.com/mertbahardogan/escope/internal/connection"
@@ -19,6 +20,18 @@ var clusterCmd = &cobra.Command{
 		client := elastic.NewClientWrapper(connection.GetClient())
 		clusterService := services.NewClusterService(client)
 
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			interval, _ := cmd.Flags().GetDuration("interval")
+			if interval < time.Second {
+				fmt.Println("Error: --interval must be at least 1s")
+				return
+			}
+			if err := tui.RunClusterWatchTUI(clusterService, interval); err != nil {
+				fmt.Printf("Error running cluster view: %v\n", err)
+			}
+			return
+		}
+
 		if err := tui.RunClusterTUI(clusterService); err != nil {
 			fmt.Printf("Error running cluster view: %v\n", err)
 		}
@@ -26,5 +39,7 @@ var clusterCmd = &cobra.Command{
 }
 
 func init() {
+	clusterCmd.Flags().BoolP("watch", "w", false, "Keep refreshing with sparklines and health status changes")
+	clusterCmd.Flags().Duration("interval", 5*time.Second, "Refresh interval for --watch")
 	core.RootCmd.AddCommand(clusterCmd)
 }
@@ -4,10 +4,15 @@ import (
 	"fmt"
 	"github.com/mertbahardogan/escope/cmd/core"
 	"github.com/mertbahardogan/escope/internal/config"
+	"github.com/mertbahardogan/escope/internal/connection"
 	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/rules"
 	"github.com/mertbahardogan/escope/internal/services"
 	"github.com/spf13/cobra"
+	"path/filepath"
 	"strconv"
+	"strings"
 )
 
 var (
@@ -16,7 +21,22 @@ var (
 	cfgPassword string
 	cfgSecure   bool
 	cfgAlias    string
+	cfgRules    string
+	cfgAPIKey   string
+	cfgBearer   string
 	clearConfig bool
+
+	cfgCACert        string
+	cfgClientCert    string
+	cfgClientKey     string
+	cfgCAFingerprint string
+	cfgInsecure      bool
+	cfgSniff         bool
+	cfgCloudID       string
+	cfgType          string
+	cfgAWSRegion     string
+	cfgAWSProfile    string
+	cfgAWSService    string
 )
 
 var configCmd = &cobra.Command{
@@ -41,16 +61,71 @@ var configCmd = &cobra.Command{
 			return
 		}
 
+		if cfgRules != "" {
+			absRules, err := filepath.Abs(cfgRules)
+			if err != nil {
+				fmt.Printf("Error: invalid rules path: %v\n", err)
+				return
+			}
+			ruleSet, err := rules.Load(absRules)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if errs := ruleSet.Validate(); len(errs) > 0 {
+				fmt.Printf("Error: rules file %s has %d problem(s), run 'escope check rules validate %s' for details\n", absRules, len(errs), absRules)
+				return
+			}
+			cfgRules = absRules
+		}
+
+		for _, path := range []*string{&cfgCACert, &cfgClientCert, &cfgClientKey} {
+			if *path == "" {
+				continue
+			}
+			abs, err := filepath.Abs(*path)
+			if err != nil {
+				fmt.Printf("Error: invalid certificate path: %v\n", err)
+				return
+			}
+			*path = abs
+		}
+
+		primary, addresses := connection.SplitHostList(cfgHost)
+		if primary == "" && cfgCloudID != "" {
+			cloudHost, err := elastic.HostFromCloudID(cfgCloudID)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			primary = cloudHost
+		}
 		c := config.ConnectionConfig{
-			Host:     cfgHost,
-			Username: cfgUsername,
-			Password: cfgPassword,
-			Secure:   cfgSecure,
+			Host:               primary,
+			Addresses:          addresses,
+			Sniff:              cfgSniff,
+			CloudID:            cfgCloudID,
+			Type:               cfgType,
+			AWSRegion:          cfgAWSRegion,
+			AWSProfile:         cfgAWSProfile,
+			AWSService:         cfgAWSService,
+			Username:           cfgUsername,
+			Password:           cfgPassword,
+			Secure:             cfgSecure,
+			RulesFile:          cfgRules,
+			APIKey:             cfgAPIKey,
+			BearerToken:        cfgBearer,
+			CACert:             cfgCACert,
+			ClientCert:         cfgClientCert,
+			ClientKey:          cfgClientKey,
+			CAFingerprint:      cfgCAFingerprint,
+			InsecureSkipVerify: cfgInsecure,
 		}
 
 		fmt.Println(constants.MsgConnectionTesting)
 		if err := configService.SaveHost(cfgAlias, c); err != nil {
 			fmt.Printf("Error: %s\n", constants.ErrConnectionTestFailed)
+			fmt.Printf("  %v\n", err)
 			return
 		}
 		fmt.Println(constants.MsgConnectionTestPassed)
@@ -113,17 +188,107 @@ var configGetCmd = &cobra.Command{
 
 		fmt.Printf("Configuration for host '%s':\n", alias)
 		fmt.Printf(constants.MsgHostLabel+"\n", savedConfig.Host)
+		if len(savedConfig.Addresses) > 0 {
+			fmt.Printf(constants.MsgAddressesLabel+"\n", strings.Join(savedConfig.Addresses, ", "))
+		}
+		if savedConfig.Sniff {
+			fmt.Printf(constants.MsgSniffLabel+"\n", savedConfig.Sniff)
+		}
+		if savedConfig.CloudID != "" {
+			fmt.Printf(constants.MsgCloudIDLabel+"\n", savedConfig.CloudID)
+		}
+		if savedConfig.Type != "" {
+			fmt.Printf(constants.MsgTypeLabel+"\n", savedConfig.Type)
+		}
+		if savedConfig.AWSRegion != "" {
+			service, profile := savedConfig.AWSService, savedConfig.AWSProfile
+			if service == "" {
+				service = "es"
+			}
+			if profile == "" {
+				profile = "default"
+			}
+			fmt.Printf(constants.MsgAWSSigningLabel+"\n", savedConfig.AWSRegion, service, profile)
+		}
 
 		if savedConfig.Secure {
 			fmt.Printf(constants.MsgUsernameLabel+"\n", savedConfig.Username)
 			if savedConfig.Password != "" {
-				fmt.Printf(constants.MsgPasswordLabel+"\n", constants.MsgPasswordHidden)
+				fmt.Printf(constants.MsgPasswordLabel+"\n", maskSecret(savedConfig.Password))
 			} else {
 				fmt.Printf(constants.MsgPasswordLabel+"\n", constants.MsgPasswordNotSet)
 			}
 		}
 
 		fmt.Printf(constants.MsgSecureLabel+"\n", savedConfig.Secure)
+		if savedConfig.APIKey != "" {
+			fmt.Printf(constants.MsgAPIKeyLabel+"\n", maskSecret(savedConfig.APIKey))
+		}
+		if savedConfig.BearerToken != "" {
+			fmt.Printf(constants.MsgBearerTokenLabel+"\n", maskSecret(savedConfig.BearerToken))
+		}
+		if savedConfig.CACert != "" {
+			fmt.Printf(constants.MsgCACertLabel+"\n", savedConfig.CACert)
+		}
+		if savedConfig.ClientCert != "" {
+			fmt.Printf(constants.MsgClientCertLabel+"\n", savedConfig.ClientCert)
+			fmt.Printf(constants.MsgClientKeyLabel+"\n", savedConfig.ClientKey)
+		}
+		if savedConfig.CAFingerprint != "" {
+			fmt.Printf(constants.MsgCAFingerprintLabel+"\n", savedConfig.CAFingerprint)
+		}
+		if savedConfig.InsecureSkipVerify {
+			fmt.Printf(constants.MsgInsecureSkipLabel+"\n", savedConfig.InsecureSkipVerify)
+		}
+		if savedConfig.RulesFile != "" {
+			fmt.Printf(constants.MsgRulesFileLabel+"\n", savedConfig.RulesFile)
+		}
+	},
+}
+
+// maskSecret hides stored secrets but shows env:/file: references, which hold no secret themselves
+func maskSecret(value string) string {
+	if config.IsSecretReference(value) {
+		return value
+	}
+	return constants.MsgPasswordHidden
+}
+
+var configEncryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Encrypt saved passwords, API keys and bearer tokens with a passphrase",
+	Long: `Encrypt saved passwords, API keys and bearer tokens with a passphrase-derived key
+(scrypt + AES-256-GCM). The passphrase is read from ESCOPE_PASSPHRASE or prompted for.
+env: and file: references are left as they are. Hosts saved afterwards are encrypted too.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		configService := services.NewConfigService()
+
+		passphrase, err := config.ReadPassphrase(true)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		count, err := configService.EncryptSecrets(passphrase)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("Config encrypted, %d secret(s) protected.\n", count)
+	},
+}
+
+var configDecryptCmd = &cobra.Command{
+	Use:   "decrypt",
+	Short: "Store saved secrets as plaintext again and disable encryption",
+	Run: func(cmd *cobra.Command, args []string) {
+		configService := services.NewConfigService()
+
+		count, err := configService.DecryptSecrets()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("Config decrypted, %d secret(s) stored as plaintext.\n", count)
 	},
 }
 
@@ -260,11 +425,25 @@ var configTimeoutCmd = &cobra.Command{
 }
 
 func init() {
-	configCmd.Flags().StringVar(&cfgHost, "host", "", "Elasticsearch host address (required)")
+	configCmd.Flags().StringVar(&cfgHost, "host", "", "Elasticsearch host address (required), comma-separated for several nodes")
+	configCmd.Flags().BoolVar(&cfgSniff, "sniff", false, "Discover the cluster's nodes on start and use them for failover")
+	configCmd.Flags().StringVar(&cfgCloudID, "cloud-id", "", "Elastic Cloud deployment ID, used instead of --host")
+	configCmd.Flags().StringVar(&cfgType, "type", "", "Connection type: elasticsearch (default) or opensearch")
+	configCmd.Flags().StringVar(&cfgAWSRegion, "aws-region", "", "Sign requests with AWS SigV4 for this region (requires --type opensearch)")
+	configCmd.Flags().StringVar(&cfgAWSProfile, "aws-profile", "", "Profile in the AWS shared credentials file (default: env credentials, then AWS_PROFILE or default)")
+	configCmd.Flags().StringVar(&cfgAWSService, "aws-service", "", "SigV4 service name: es (default) or aoss for OpenSearch Serverless")
 	configCmd.Flags().StringVar(&cfgUsername, "username", "", "Username (required in secure mode)")
-	configCmd.Flags().StringVar(&cfgPassword, "password", "", "Password (required in secure mode)")
+	configCmd.Flags().StringVar(&cfgPassword, "password", "", "Password (required in secure mode), or env:VAR / file:/path")
 	configCmd.Flags().BoolVar(&cfgSecure, "secure", false, "Connect with username and password (default: false)")
 	configCmd.Flags().StringVar(&cfgAlias, "alias", "", "Host alias name (required)")
+	configCmd.Flags().StringVar(&cfgAPIKey, "api-key", "", "API key, either 'id:api_key' or the encoded value")
+	configCmd.Flags().StringVar(&cfgBearer, "bearer-token", "", "Bearer token, e.g. an Elastic Cloud service token")
+	configCmd.Flags().StringVar(&cfgCACert, "ca-cert", "", "PEM file with the CA certificate(s) used to verify the server")
+	configCmd.Flags().StringVar(&cfgClientCert, "client-cert", "", "PEM client certificate for mutual TLS (requires --client-key)")
+	configCmd.Flags().StringVar(&cfgClientKey, "client-key", "", "PEM private key of the client certificate")
+	configCmd.Flags().StringVar(&cfgCAFingerprint, "ca-fingerprint", "", "Hex SHA-256 fingerprint of the CA certificate to pin")
+	configCmd.Flags().BoolVar(&cfgInsecure, "insecure-skip-verify", false, "Skip server certificate verification (not recommended)")
+	configCmd.Flags().StringVar(&cfgRules, "rules", "", "Check rules file used by 'escope check' for this host")
 	configCmd.Flags().BoolVar(&clearConfig, "clear", false, "Clear saved connection config")
 
 	configCmd.AddCommand(configGetCmd)
@@ -274,5 +453,7 @@ func init() {
 	configCmd.AddCommand(configListCmd)
 	configCmd.AddCommand(configDeleteCmd)
 	configCmd.AddCommand(configTimeoutCmd)
+	configCmd.AddCommand(configEncryptCmd)
+	configCmd.AddCommand(configDecryptCmd)
 	core.RootCmd.AddCommand(configCmd)
 }
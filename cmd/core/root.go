@@ -1,18 +1,33 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"github.com/mertbahardogan/escope/internal/connection"
 	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/output"
 	"github.com/spf13/cobra"
+	"os"
+	"strings"
 )
 
 var (
-	host     string
-	username string
-	password string
-	secure   bool
-	alias    string
+	host       string
+	username   string
+	password   string
+	secure     bool
+	sniff      bool
+	cloudID    string
+	connType   string
+	awsRegion  string
+	awsProfile string
+	alias      string
+	apiKey     string
+	bearer     string
+
+	outputFlag   string
+	outputFormat = output.FormatTable
 )
 
 var RootCmd = &cobra.Command{
@@ -22,6 +37,12 @@ var RootCmd = &cobra.Command{
 	SilenceUsage:       true,
 	DisableSuggestions: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		format, err := output.ParseFormat(outputFlag)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return err
+		}
+		outputFormat = format
 		return validateConfig(cmd)
 	},
 	Run: func(cmd *cobra.Command, args []string) {
@@ -29,14 +50,51 @@ var RootCmd = &cobra.Command{
 	},
 }
 
+// reportServedBy tells the user which node served the command when the connection has
+// several nodes, and which nodes could not be reached before it. It goes to stderr to
+// keep structured output clean.
+func reportServedBy() {
+	servedBy, failed := connection.ServedBy()
+	if servedBy == "" {
+		return
+	}
+	if len(failed) > 0 {
+		fmt.Fprintf(os.Stderr, constants.MsgServedByFailover+"\n", servedBy, strings.Join(failed, ", "))
+		return
+	}
+	if connection.IsMultiNode() {
+		fmt.Fprintf(os.Stderr, constants.MsgServedBy+"\n", servedBy)
+	}
+}
+
+// servedByNode returns the node to record in the structured output envelope. It is only
+// set when more than one node could have answered.
+func servedByNode() string {
+	servedBy, failed := connection.ServedBy()
+	if len(failed) == 0 && !connection.IsMultiNode() {
+		return ""
+	}
+	return servedBy
+}
+
+// SkipConnectionAnnotation marks commands that work offline and need no host configuration
+const SkipConnectionAnnotation = "escope/skip-connection"
+
 func validateConfig(cmd *cobra.Command) error {
+	if _, ok := cmd.Annotations[SkipConnectionAnnotation]; ok {
+		return nil
+	}
 	if cmd.Name() == "escope" || cmd.Name() == "config" || cmd.Name() == "clear" ||
 		(cmd.Parent() != nil && cmd.Parent().Name() == "config") {
 		return nil
 	}
 
 	if alias != "" {
-		savedConfig := connection.GetSavedConfig(alias)
+		savedConfig, err := connection.LoadSavedConfig(alias)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("Error: %v\n", err)
+			return err
+		}
 		if savedConfig.Host == "" {
 			fmt.Printf("Error: Host alias '%s' not found. Available aliases:\n", alias)
 			aliases, err := connection.ListSavedConfigs()
@@ -53,12 +111,33 @@ func validateConfig(cmd *cobra.Command) error {
 		return nil
 	}
 
-	if host != "" {
+	if host != "" || cloudID != "" {
+		primary, addresses := connection.SplitHostList(host)
+		if primary == "" {
+			cloudHost, err := elastic.HostFromCloudID(cloudID)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return err
+			}
+			primary = cloudHost
+		}
+		if err := elastic.ValidateType(connType); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return err
+		}
 		connection.SetConfig(connection.Config{
-			Host:     host,
-			Username: username,
-			Password: password,
-			Secure:   secure,
+			Host:        primary,
+			Addresses:   addresses,
+			Sniff:       sniff,
+			CloudID:     cloudID,
+			Type:        connType,
+			AWSRegion:   awsRegion,
+			AWSProfile:  awsProfile,
+			Username:    username,
+			Password:    password,
+			Secure:      secure,
+			APIKey:      apiKey,
+			BearerToken: bearer,
 		})
 		return nil
 	}
@@ -90,7 +169,11 @@ func validateConfig(cmd *cobra.Command) error {
 		return fmt.Errorf("no active host set")
 	}
 
-	savedConfig := connection.GetSavedConfig(activeHost)
+	savedConfig, err := connection.LoadSavedConfig(activeHost)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Printf("Error: %v\n", err)
+		return err
+	}
 	if savedConfig.Host == "" {
 		fmt.Printf("Error: Active host '%s' not found. Available hosts:\n", activeHost)
 		for _, a := range aliases {
@@ -105,15 +188,51 @@ func validateConfig(cmd *cobra.Command) error {
 }
 
 func init() {
-	RootCmd.PersistentFlags().StringVarP(&host, "host", "H", "", "Elasticsearch host address (required for most commands)")
+	RootCmd.PersistentFlags().StringVarP(&host, "host", "H", "", "Elasticsearch host address (required for most commands), comma-separated for several nodes")
+	RootCmd.PersistentFlags().BoolVar(&sniff, "sniff", false, "Discover the cluster's nodes on start and use them for failover")
+	RootCmd.PersistentFlags().StringVar(&cloudID, "cloud-id", "", "Elastic Cloud deployment ID, used instead of --host")
+	RootCmd.PersistentFlags().StringVar(&connType, "type", "", "Connection type: elasticsearch (default) or opensearch")
+	RootCmd.PersistentFlags().StringVar(&awsRegion, "aws-region", "", "Sign requests with AWS SigV4 for this region (requires --type opensearch)")
+	RootCmd.PersistentFlags().StringVar(&awsProfile, "aws-profile", "", "Profile in the AWS shared credentials file")
 	RootCmd.PersistentFlags().StringVarP(&username, "username", "u", "", "Username (required in secure mode)")
 	RootCmd.PersistentFlags().StringVarP(&password, "password", "p", "", "Password (required in secure mode)")
 	RootCmd.PersistentFlags().BoolVar(&secure, "secure", false, "Connect with username and password (default: false)")
+	RootCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "API key, either 'id:api_key' or the encoded value")
+	RootCmd.PersistentFlags().StringVar(&bearer, "bearer-token", "", "Bearer token, e.g. an Elastic Cloud service token")
 	RootCmd.PersistentFlags().StringVarP(&alias, "alias", "a", "", "Use a saved host alias instead of specifying connection details")
+	RootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", string(output.FormatTable),
+		"Output format: "+output.SupportedFormats())
+
+}
+
+// OutputFormat returns the format selected with --output
+func OutputFormat() output.Format {
+	return outputFormat
+}
+
+// WriteOutput emits v to stdout in the selected structured format
+func WriteOutput(kind string, v interface{}) {
+	if err := output.WriteServedBy(os.Stdout, outputFormat, kind, servedByNode(), v); err != nil {
+		fmt.Fprintf(os.Stderr, "Output encoding failed: %v\n", err)
+	}
+}
 
+// ExitError lets a command finish its output and still end the process with a non-zero code
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
 }
 
 func Execute() {
-	if err := RootCmd.Execute(); err != nil {
+	err := RootCmd.Execute()
+	reportServedBy()
+	if err != nil {
+		var exitErr *ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 	}
 }
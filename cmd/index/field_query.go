@@ -6,6 +6,7 @@ import (
 
 	"github.com/mertbahardogan/escope/internal/connection"
 	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/indexsession"
 	"github.com/mertbahardogan/escope/internal/services"
 	"github.com/mertbahardogan/escope/internal/ui"
 	"github.com/mertbahardogan/escope/internal/util"
@@ -32,10 +33,10 @@ Use --nested for nested fields: path is the first segment of --field
 		value, _ := cmd.Flags().GetString("value")
 		nested, _ := cmd.Flags().GetBool("nested")
 
-		name = resolveIndexName(name)
+		name = indexsession.ResolveIndexName(name)
 		if name == "" || field == "" {
 			if name == "" {
-				printIndexNameRequired()
+				indexsession.PrintIndexNameRequired()
 			} else {
 				fmt.Println("Error: --field is required")
 			}
@@ -63,10 +64,10 @@ For analyzed text you usually need a .keyword (or other doc_values) subfield.`,
 		field, _ := cmd.Flags().GetString("field")
 		nested, _ := cmd.Flags().GetBool("nested")
 
-		name = resolveIndexName(name)
+		name = indexsession.ResolveIndexName(name)
 		if name == "" || field == "" {
 			if name == "" {
-				printIndexNameRequired()
+				indexsession.PrintIndexNameRequired()
 			} else {
 				fmt.Println("Error: --field is required")
 			}
@@ -0,0 +1,74 @@
+package index
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/mertbahardogan/escope/internal/connection"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/indexsession"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui"
+	"github.com/mertbahardogan/escope/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var fieldUsageCmd = &cobra.Command{
+	Use:   "field-usage",
+	Short: "Show which fields are queried, aggregated or loaded, and which are never used",
+	Long: `Sums the _field_usage_stats of every shard per field and shows how each field was read:
+through the inverted index (term lookups), doc values (sorting, aggregations, scripts),
+stored fields, norms (scoring) or points (numeric and date ranges).
+
+Mapped fields that no shard has accessed are listed at the end as candidates for removal.
+Usage is tracked per shard copy since it was opened, so check the tracking window: a shard
+that relocated or whose node restarted starts again from zero.
+
+Examples:
+  escope index field-usage -n my-index
+  escope index field-usage -n logs-* --unused`,
+	SilenceErrors:      true,
+	DisableSuggestions: true,
+	Args:               cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		unusedOnly, _ := cmd.Flags().GetBool("unused")
+
+		name = indexsession.ResolveIndexName(name)
+		if name == "" {
+			indexsession.PrintIndexNameRequired()
+			fmt.Println("Usage: escope index field-usage [--name <index-or-alias>] [--unused]")
+			return
+		}
+
+		runFieldUsage(name, unusedOnly)
+	},
+}
+
+func runFieldUsage(indexName string, unusedOnly bool) {
+	client := elastic.NewClientWrapper(connection.GetClient())
+	fieldUsageService := services.NewFieldUsageService(client)
+
+	report, err := util.ExecuteWithTimeout(func() (*models.FieldUsageReport, error) {
+		return fieldUsageService.GetFieldUsage(context.Background(), indexName)
+	})
+	if util.HandleServiceErrorWithReturn(err, "Field usage") {
+		return
+	}
+
+	// Structured output always carries the whole report; the unused fields are in "unused"
+	if core.OutputFormat().IsStructured() {
+		core.WriteOutput(output.KindFieldUsage, report)
+		return
+	}
+	fmt.Print(ui.NewFieldUsageFormatter().FormatFieldUsage(report, unusedOnly))
+}
+
+func init() {
+	indexCmd.AddCommand(fieldUsageCmd)
+	fieldUsageCmd.Flags().StringP("name", "n", "", "Index, alias or pattern (defaults to index from 'escope index use')")
+	fieldUsageCmd.Flags().Bool("unused", false, "Only list the mapped fields that were never accessed (table output)")
+}
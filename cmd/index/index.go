@@ -16,6 +16,7 @@ import (
 	"github.com/mertbahardogan/escope/internal/constants"
 	"github.com/mertbahardogan/escope/internal/elastic"
 	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
 	"github.com/mertbahardogan/escope/internal/services"
 	"github.com/mertbahardogan/escope/internal/ui"
 	"github.com/mertbahardogan/escope/internal/util"
@@ -44,6 +45,11 @@ var indexCmd = &cobra.Command{
 		indexName, _ := cmd.Flags().GetString("name")
 		topMode, _ := cmd.Flags().GetBool("top")
 
+		if topMode && (indexName == "" || isIndexPattern(indexName)) {
+			runIndexTop(indexName)
+			return
+		}
+
 		if indexName != "" {
 			runIndexDetail(indexName, topMode)
 			return
@@ -86,6 +92,12 @@ func runIndexList() {
 		}
 	}
 
+	// Rate metrics need two samples per index, structured output only emits the catalog data
+	if core.OutputFormat().IsStructured() {
+		core.WriteOutput(output.KindIndex, filteredIndices)
+		return
+	}
+
 	metricsMap := make(map[string]IndexMetrics)
 	for _, idx := range filteredIndices {
 		detail, err := getIndexMetricsWithSnapshot(indexService, idx.Name)
@@ -222,8 +234,12 @@ func displayIndexDetail(indexService services.IndexService, formatter *ui.IndexD
 func init() {
 	core.RootCmd.AddCommand(indexCmd)
 
-	indexCmd.Flags().StringVarP(&indexName, "name", "n", "", "Show detailed information for specific index")
-	indexCmd.Flags().BoolVarP(&topMode, "top", "t", false, "Continuously monitor index (like top command)")
+	indexCmd.Flags().StringVarP(&indexName, "name", "n", "", "Show detailed information for specific index (a pattern like 'logs-*' with --top)")
+	indexCmd.Flags().BoolVarP(&topMode, "top", "t", false, "Continuously monitor index (like top command); without --name or with a pattern, all matching indices")
+	indexCmd.Flags().StringVar(&topSort, "sort", "search-rate", "Column to sort --top by: "+topSortNames())
+	indexCmd.Flags().StringVar(&topOrder, "order", "desc", "Sort order for --top: asc or desc")
+	indexCmd.Flags().DurationVar(&topInterval, "interval", 2*time.Second, "Refresh interval for --top across indices")
+	indexCmd.Flags().IntVar(&topLimit, "limit", 20, "Maximum indices shown by --top across indices (0 for all)")
 
 	system.NewSystemCommand(indexCmd, "index")
 	sort.NewSortCommand(indexCmd, "index")
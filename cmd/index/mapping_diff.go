@@ -0,0 +1,73 @@
+package index
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui"
+	"github.com/mertbahardogan/escope/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var mappingDiffCmd = &cobra.Command{
+	Use:           "diff <a> <b>",
+	Short:         "Compare the mappings of two indices, optionally on different hosts",
+	SilenceErrors: true,
+	Args:          cobra.ExactArgs(2),
+	Long: `Compare the mappings of two indices field by field and report added and removed fields,
+type changes, analyzer changes and index: true/false toggles, as seen from <a> towards <b>.
+
+Either side can be <host-alias>:<index> to read the index from another saved host, so a
+reindex target or a new environment can be checked against production before switching
+aliases.
+
+Examples:
+  escope index mapping diff logs-v1 logs-v2
+  escope index mapping diff prod:products staging:products
+  escope index mapping diff prod:products products-reindexed`,
+	Run: func(cmd *cobra.Command, args []string) {
+		left, err := fetchMappingSide(args[0])
+		if util.HandleServiceErrorWithReturn(err, "Index mapping fetch ("+args[0]+")") {
+			return
+		}
+		right, err := fetchMappingSide(args[1])
+		if util.HandleServiceErrorWithReturn(err, "Index mapping fetch ("+args[1]+")") {
+			return
+		}
+		if len(left) == 0 || len(right) == 0 {
+			empty := args[0]
+			if len(left) > 0 {
+				empty = args[1]
+			}
+			fmt.Printf("No mapping found for index '%s'\n", empty)
+			return
+		}
+
+		changes := services.DiffFieldMappings(left, right)
+		if core.OutputFormat().IsStructured() {
+			core.WriteOutput(output.KindMappingDiff, changes)
+			return
+		}
+		fmt.Print(ui.NewMappingDiffFormatter().FormatMappingDiff(args[0], args[1], changes))
+	},
+}
+
+// fetchMappingSide reads the flattened mapping of "index" on the active host, or of
+// "alias:index" on the saved host alias
+func fetchMappingSide(side string) ([]models.FieldMapping, error) {
+	indexService, indexName, err := indexServiceForSide(side)
+	if err != nil {
+		return nil, err
+	}
+	return util.ExecuteWithTimeout(func() ([]models.FieldMapping, error) {
+		return indexService.GetIndexFields(context.Background(), indexName)
+	})
+}
+
+func init() {
+	mappingCmd.AddCommand(mappingDiffCmd)
+}
@@ -0,0 +1,84 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/mertbahardogan/escope/internal/connection"
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/indexsession"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui"
+	"github.com/mertbahardogan/escope/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Profile every aggregatable field: coverage, cardinality and top values",
+	Long: `Walks every aggregatable field of the mapping (keyword, numeric, date, boolean, ip, ...)
+and reports the share of documents that have it, its approximate distinct values and, for
+keyword fields, the most frequent values.
+
+One search runs per field, at most --concurrency at a time, each with the configured timeout.
+On big indices use --sample to read at most that many documents per shard.
+
+Text fields, objects and fields inside nested objects are skipped.
+
+Examples:
+  escope index profile -n my-index
+  escope index profile -n logs-* --sample 10000 --concurrency 2
+  escope index profile -n my-index --top 10`,
+	SilenceErrors:      true,
+	DisableSuggestions: true,
+	Args:               cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		top, _ := cmd.Flags().GetInt("top")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		sample, _ := cmd.Flags().GetInt("sample")
+
+		name = indexsession.ResolveIndexName(name)
+		if name == "" {
+			indexsession.PrintIndexNameRequired()
+			fmt.Println("Usage: escope index profile [--name <index-or-alias>] [--top N] [--concurrency N] [--sample N]")
+			return
+		}
+
+		runIndexProfile(name, models.ProfileOptions{TopValues: top, Concurrency: concurrency, SampleSize: sample})
+	},
+}
+
+func runIndexProfile(indexName string, opts models.ProfileOptions) {
+	client := elastic.NewClientWrapper(connection.GetClient())
+	profileService := services.NewProfileService(client)
+
+	fmt.Fprintf(os.Stderr, "Profiling fields of %s (%d at a time)...\n", indexName, opts.Concurrency)
+	profile, err := profileService.ProfileIndex(context.Background(), indexName, opts)
+	if util.HandleServiceErrorWithReturn(err, "Index profile") {
+		return
+	}
+	if len(profile.Fields) == 0 {
+		fmt.Printf("No aggregatable fields found for index '%s'\n", indexName)
+		return
+	}
+
+	if core.OutputFormat().IsStructured() {
+		core.WriteOutput(output.KindIndexProfile, profile.Fields)
+		return
+	}
+	fmt.Print(ui.NewProfileFormatter().FormatIndexProfile(profile))
+}
+
+func init() {
+	indexCmd.AddCommand(profileCmd)
+	profileCmd.Flags().StringP("name", "n", "", "Index or alias (defaults to index from 'escope index use')")
+	profileCmd.Flags().Int("top", constants.DefaultProfileTopValues, "Most frequent values shown per keyword field (0 to skip)")
+	profileCmd.Flags().Int("concurrency", constants.DefaultProfileConcurrency, "Field searches run at the same time")
+	profileCmd.Flags().Int("sample", 0, "Read at most this many documents per shard (0 reads every document)")
+}
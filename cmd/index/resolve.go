@@ -4,20 +4,26 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/mertbahardogan/escope/internal/indexsession"
+	"github.com/mertbahardogan/escope/internal/connection"
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/services"
 )
 
-func resolveIndexName(flagValue string) string {
-	if s := strings.TrimSpace(flagValue); s != "" {
-		return s
+// indexServiceForSide resolves one side of a diff: "index" on the active host, or
+// "alias:index" on the saved host alias
+func indexServiceForSide(side string) (services.IndexService, string, error) {
+	client := connection.GetClient()
+	indexName := side
+	if alias, name, ok := strings.Cut(side, ":"); ok {
+		if alias == "" || name == "" {
+			return nil, "", fmt.Errorf(constants.ErrInvalidIndexSide, side)
+		}
+		hostClient, err := connection.NewClientForHost(alias)
+		if err != nil {
+			return nil, "", err
+		}
+		client, indexName = hostClient, name
 	}
-	if idx, ok := indexsession.ReadSelectedIndex(); ok {
-		return idx
-	}
-	return ""
-}
-
-func printIndexNameRequired() {
-	fmt.Println("Error: no index specified.")
-	fmt.Println("Use --name <index-or-alias>, or select once with: escope index use <index-or-alias>")
+	return services.NewIndexService(elastic.NewClientWrapper(client)), indexName, nil
 }
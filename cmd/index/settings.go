@@ -7,6 +7,7 @@ import (
 
 	"github.com/mertbahardogan/escope/internal/connection"
 	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/indexsession"
 	"github.com/mertbahardogan/escope/internal/models"
 	"github.com/mertbahardogan/escope/internal/services"
 	"github.com/mertbahardogan/escope/internal/ui"
@@ -22,10 +23,10 @@ var settingsCmd = &cobra.Command{
 	Args:               cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
 		flagName, _ := cmd.Flags().GetString("name")
-		name := resolveIndexName(flagName)
+		name := indexsession.ResolveIndexName(flagName)
 
 		if name == "" {
-			printIndexNameRequired()
+			indexsession.PrintIndexNameRequired()
 			fmt.Println("Usage: escope index settings [--name <index-name>]")
 			return
 		}
@@ -0,0 +1,164 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/mertbahardogan/escope/internal/connection"
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/indexsession"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui"
+	"github.com/mertbahardogan/escope/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	settingsDiffExitCode   bool
+	settingsVerifyFile     string
+	settingsVerifyPattern  string
+	settingsVerifyExitCode bool
+)
+
+var settingsDiffCmd = &cobra.Command{
+	Use:           "diff <a> <b>",
+	Short:         "Compare the settings of two indices, optionally on different hosts",
+	SilenceErrors: true,
+	Args:          cobra.ExactArgs(2),
+	Long: `Compare the settings of two indices key by key. Keys set per index by Elasticsearch
+(uuid, creation_date, provided_name, version.*) are ignored.
+
+Either side can be <host-alias>:<index> to read the index from another saved host. With
+--exit-code the command exits with 2 when the settings differ, for use in CI.
+
+Examples:
+  escope index settings diff logs-v1 logs-v2
+  escope index settings diff prod:products staging:products --exit-code`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		left, err := fetchSettingsSide(args[0])
+		if util.HandleServiceErrorWithReturn(err, "Index settings fetch ("+args[0]+")") {
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+		right, err := fetchSettingsSide(args[1])
+		if util.HandleServiceErrorWithReturn(err, "Index settings fetch ("+args[1]+")") {
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+		if len(left) == 0 || len(right) == 0 {
+			empty := args[0]
+			if len(left) > 0 {
+				empty = args[1]
+			}
+			fmt.Printf("No settings found for index '%s'\n", empty)
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+
+		changes := services.DiffIndexSettings(left, right)
+		if core.OutputFormat().IsStructured() {
+			core.WriteOutput(output.KindSettingsDiff, changes)
+		} else {
+			fmt.Print(ui.NewSettingsDiffFormatter().FormatSettingsDiff(args[0], args[1], changes))
+		}
+		if settingsDiffExitCode && len(changes) > 0 {
+			return &core.ExitError{Code: constants.ExitCodeDrift}
+		}
+		return nil
+	},
+}
+
+var settingsVerifyCmd = &cobra.Command{
+	Use:           "verify",
+	Short:         "Check the settings of matching indices against a desired-state file",
+	SilenceErrors: true,
+	Args:          cobra.ExactArgs(0),
+	Long: `Compare the live settings of every index matching --pattern with a desired-state file and
+report the drift per index. Only the settings in the file are checked; a setting never set
+on an index is compared by its default value.
+
+The file is YAML or JSON, nested or flat, optionally under a top-level "settings" key; the
+"index." prefix may be left out:
+
+  settings:
+    number_of_replicas: 1
+    refresh_interval: 30s
+    index.codec: best_compression
+
+With --exit-code the command exits with 2 when any index drifted, for use in CI.
+
+Examples:
+  escope index settings verify --file desired.yaml --pattern logs-*
+  escope index settings verify --file desired.yaml --pattern logs-* --exit-code`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pattern := indexsession.ResolveIndexName(settingsVerifyPattern)
+		if settingsVerifyFile == "" || pattern == "" {
+			fmt.Println("Usage: escope index settings verify --file <desired.yaml> --pattern <index-pattern>")
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+
+		desired, err := services.LoadDesiredSettings(settingsVerifyFile)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+
+		indexService := services.NewIndexService(elastic.NewClientWrapper(connection.GetClient()))
+		live, err := util.ExecuteWithTimeout(func() (map[string][]models.IndexSettingInfo, error) {
+			return indexService.GetIndicesSettings(context.Background(), pattern)
+		})
+		if util.HandleServiceErrorWithReturn(err, "Index settings fetch") {
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+		if len(live) == 0 {
+			fmt.Printf("No indices match '%s'\n", pattern)
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+
+		results := make([]models.IndexSettingsDrift, 0, len(live))
+		drifted := 0
+		for name, settings := range live {
+			drift := services.VerifyIndexSettings(desired, settings)
+			results = append(results, models.IndexSettingsDrift{Index: name, InSync: len(drift) == 0, Drift: drift})
+			if len(drift) > 0 {
+				drifted++
+			}
+		}
+		sort.Slice(results, func(i, j int) bool {
+			return results[i].Index < results[j].Index
+		})
+
+		if core.OutputFormat().IsStructured() {
+			core.WriteOutput(output.KindSettingsDrift, results)
+		} else {
+			fmt.Print(ui.NewSettingsDiffFormatter().FormatSettingsDrift(settingsVerifyFile, len(desired), results))
+		}
+		if settingsVerifyExitCode && drifted > 0 {
+			return &core.ExitError{Code: constants.ExitCodeDrift}
+		}
+		return nil
+	},
+}
+
+// fetchSettingsSide reads the flattened settings of "index" on the active host, or of
+// "alias:index" on the saved host alias
+func fetchSettingsSide(side string) ([]models.IndexSettingInfo, error) {
+	indexService, indexName, err := indexServiceForSide(side)
+	if err != nil {
+		return nil, err
+	}
+	return util.ExecuteWithTimeout(func() ([]models.IndexSettingInfo, error) {
+		return indexService.GetIndexSettings(context.Background(), indexName)
+	})
+}
+
+func init() {
+	settingsCmd.AddCommand(settingsDiffCmd)
+	settingsCmd.AddCommand(settingsVerifyCmd)
+	settingsDiffCmd.Flags().BoolVar(&settingsDiffExitCode, "exit-code", false, "Exit with 2 when the settings differ")
+	settingsVerifyCmd.Flags().StringVarP(&settingsVerifyFile, "file", "f", "", "Desired-state settings file (YAML or JSON)")
+	settingsVerifyCmd.Flags().StringVar(&settingsVerifyPattern, "pattern", "", "Index name or pattern to verify (defaults to index from 'escope index use')")
+	settingsVerifyCmd.Flags().BoolVar(&settingsVerifyExitCode, "exit-code", false, "Exit with 2 when any index drifted from the desired state")
+}
@@ -0,0 +1,145 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mertbahardogan/escope/internal/connection"
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+var (
+	topSort     string
+	topOrder    string
+	topInterval time.Duration
+	topLimit    int
+)
+
+// topSortKeys maps --sort values to the column they sort by
+var topSortKeys = map[string]func(models.IndexTopInfo) float64{
+	"search-rate":  func(i models.IndexTopInfo) float64 { return i.Rates.SearchRate },
+	"index-rate":   func(i models.IndexTopInfo) float64 { return i.Rates.IndexRate },
+	"query-time":   func(i models.IndexTopInfo) float64 { return i.Rates.AvgQueryTime },
+	"index-time":   func(i models.IndexTopInfo) float64 { return i.Rates.AvgIndexTime },
+	"refresh-time": func(i models.IndexTopInfo) float64 { return i.Rates.AvgRefreshTime },
+	"merge-time":   func(i models.IndexTopInfo) float64 { return i.Rates.AvgMergeTime },
+}
+
+func isIndexPattern(name string) bool {
+	return strings.ContainsAny(name, "*,")
+}
+
+func topSortNames() string {
+	names := []string{"name"}
+	for name := range topSortKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// runIndexTop refreshes the rates of all indices (or those matching pattern) every interval
+func runIndexTop(pattern string) {
+	key, ok := topSortKeys[topSort]
+	if !ok && topSort != "name" {
+		fmt.Printf("Error: unknown sort column '%s' (available: %s)\n", topSort, topSortNames())
+		return
+	}
+	if topOrder != "asc" && topOrder != "desc" {
+		fmt.Printf("Error: --order must be asc or desc\n")
+		return
+	}
+	if topInterval < time.Second {
+		fmt.Println("Error: --interval must be at least 1s")
+		return
+	}
+
+	client := elastic.NewClientWrapper(connection.GetClient())
+	indexService := services.NewIndexService(client)
+	formatter := ui.NewIndexTopFormatter()
+
+	scope := pattern
+	if scope == "" {
+		scope = "all indices"
+	}
+
+	// The first call only seeds the snapshots the rates are computed from
+	if _, ok := fetchIndexTop(indexService, pattern); !ok {
+		return
+	}
+	fmt.Printf("Sampling %s for %s...\n", scope, topInterval)
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	ticker := time.NewTicker(topInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c:
+			return
+		case <-ticker.C:
+			infos, ok := fetchIndexTop(indexService, pattern)
+			if !ok {
+				return
+			}
+			total := len(infos)
+			sortIndexTop(infos, key, topOrder == "desc")
+			if topLimit > 0 && len(infos) > topLimit {
+				infos = infos[:topLimit]
+			}
+			fmt.Print(constants.ANSIClearScreen)
+			fmt.Print(formatter.FormatIndexTop(infos, scope, topSort+" "+topOrder, total, time.Now()))
+		}
+	}
+}
+
+func fetchIndexTop(indexService services.IndexService, pattern string) ([]models.IndexTopInfo, bool) {
+	infos, err := util.ExecuteWithTimeout(func() ([]models.IndexTopInfo, error) {
+		return indexService.GetIndexTopInfos(context.Background(), pattern)
+	})
+	if util.HandleServiceErrorWithReturn(err, "Index stats fetch") {
+		return nil, false
+	}
+	if pattern != "" {
+		return infos, true
+	}
+
+	filtered := infos[:0]
+	for _, info := range infos {
+		if !util.IsSystemIndex(info.Name) {
+			filtered = append(filtered, info)
+		}
+	}
+	return filtered, true
+}
+
+// sortIndexTop sorts by key, or by name when key is nil; ties are broken by name
+func sortIndexTop(infos []models.IndexTopInfo, key func(models.IndexTopInfo) float64, desc bool) {
+	sort.Slice(infos, func(i, j int) bool {
+		if key != nil {
+			a, b := key(infos[i]), key(infos[j])
+			if a != b {
+				if desc {
+					return a > b
+				}
+				return a < b
+			}
+		}
+		if desc && key == nil {
+			return infos[i].Name > infos[j].Name
+		}
+		return infos[i].Name < infos[j].Name
+	})
+}
@@ -0,0 +1,93 @@
+package index
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/mertbahardogan/escope/internal/connection"
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/indexsession"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui"
+	"github.com/mertbahardogan/escope/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var valuesCmd = &cobra.Command{
+	Use:   "values",
+	Short: "Show what a field holds: top values or a histogram",
+	Long: `Aggregates one field according to its mapped type:
+
+  keyword, boolean, ip, ...   top values with document counts and share of the total
+  numeric                     histogram, drawn as a bar chart
+  date                        date histogram, drawn as a bar chart
+
+Without --interval the histogram interval is picked to give about --buckets buckets.
+--query narrows the documents, in query_string syntax or as a JSON query object.
+
+Use --nested for values inside a nested mapping: path is the first segment of --field.
+
+Examples:
+  escope index values -n my-index -f status.keyword --top 20
+  escope index values -n logs-* -f @timestamp --interval 1h --query 'status:500'
+  escope index values -n my-index -f price --buckets 10
+  escope index values -n my-index -f status.keyword --query '{"range":{"@timestamp":{"gte":"now-1d"}}}'`,
+	SilenceErrors:      true,
+	DisableSuggestions: true,
+	Args:               cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		field, _ := cmd.Flags().GetString("field")
+		nested, _ := cmd.Flags().GetBool("nested")
+		top, _ := cmd.Flags().GetInt("top")
+		buckets, _ := cmd.Flags().GetInt("buckets")
+		interval, _ := cmd.Flags().GetString("interval")
+		query, _ := cmd.Flags().GetString("query")
+
+		name = indexsession.ResolveIndexName(name)
+		if name == "" || field == "" {
+			if name == "" {
+				indexsession.PrintIndexNameRequired()
+			} else {
+				fmt.Println("Error: --field is required")
+			}
+			fmt.Println("Usage: escope index values [--name <index-or-alias>] --field <field> [--top N] [--interval <interval>] [--query <query>]")
+			return
+		}
+
+		runFieldValues(name, field, models.ValuesOptions{Top: top, Buckets: buckets, Interval: interval, Query: query, Nested: nested})
+	},
+}
+
+func runFieldValues(indexName, field string, opts models.ValuesOptions) {
+	client := elastic.NewClientWrapper(connection.GetClient())
+	valuesService := services.NewValuesService(client)
+
+	values, err := util.ExecuteWithTimeout(func() (*models.FieldValues, error) {
+		return valuesService.GetFieldValues(context.Background(), indexName, field, opts)
+	})
+	if util.HandleServiceErrorWithReturn(err, "Field values") {
+		return
+	}
+
+	if core.OutputFormat().IsStructured() {
+		core.WriteOutput(output.KindFieldValues, values.Buckets)
+		return
+	}
+	fmt.Print(ui.NewValuesFormatter().FormatFieldValues(values))
+}
+
+func init() {
+	indexCmd.AddCommand(valuesCmd)
+	valuesCmd.Flags().StringP("name", "n", "", "Index or alias (defaults to index from 'escope index use')")
+	valuesCmd.Flags().StringP("field", "f", "", "Field path (required)")
+	valuesCmd.Flags().Bool("nested", false, "Nested aggregation: path is the first segment of --field")
+	valuesCmd.Flags().Int("top", constants.DefaultValuesTop, "Number of top values for keyword-like fields")
+	valuesCmd.Flags().Int("buckets", constants.DefaultValuesBuckets, "Target number of histogram buckets when --interval is not set")
+	valuesCmd.Flags().String("interval", "", "Histogram interval: a number for numeric fields, 1h/1d/1M... for dates")
+	valuesCmd.Flags().StringP("query", "q", "", "Filter documents: query_string syntax or a JSON query object")
+}
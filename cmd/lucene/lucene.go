@@ -9,6 +9,7 @@ import (
 	"github.com/mertbahardogan/escope/internal/constants"
 	"github.com/mertbahardogan/escope/internal/elastic"
 	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
 	"github.com/mertbahardogan/escope/internal/services"
 	"github.com/mertbahardogan/escope/internal/ui"
 	"github.com/mertbahardogan/escope/internal/util"
@@ -70,6 +71,11 @@ var luceneCmd = &cobra.Command{
 			return filteredStats[i].SegmentMemoryBytes > filteredStats[j].SegmentMemoryBytes
 		})
 
+		if core.OutputFormat().IsStructured() {
+			core.WriteOutput(output.KindLucene, filteredStats)
+			return
+		}
+
 		headers := []string{"Segments", "Total Memory", "Terms Memory", "Stored Memory", "DocValues", "Index"}
 		rows := make([][]string, 0, len(filteredStats))
 
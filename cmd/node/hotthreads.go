@@ -0,0 +1,92 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/mertbahardogan/escope/internal/connection"
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui"
+	"github.com/mertbahardogan/escope/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hotThreadsNode      string
+	hotThreadsType      string
+	hotThreadsSnapshots int
+	hotThreadsSave      string
+)
+
+var nodeHotThreadsCmd = &cobra.Command{
+	Use:           "hotthreads",
+	Short:         "Capture and parse hot threads per node",
+	SilenceErrors: true,
+	Long: `Capture _nodes/hot_threads and show the busiest threads with their CPU (or wait/block)
+share and top stack frames, then group threads that share the same stack.
+
+Use --save to keep the raw text the cluster returned next to the parsed report.
+
+Examples:
+  escope node hotthreads
+  escope node hotthreads --name data-node-1 --snapshots 20
+  escope node hotthreads --thread-type wait
+  escope node hotthreads --save hot_threads.txt`,
+	Run: func(cmd *cobra.Command, args []string) {
+		switch hotThreadsType {
+		case constants.HotThreadsTypeCPU, constants.HotThreadsTypeWait, constants.HotThreadsTypeBlock:
+		default:
+			fmt.Printf(constants.ErrInvalidHotThreadsType+"\n", hotThreadsType)
+			return
+		}
+
+		ctx := context.Background()
+		client := elastic.NewClientWrapper(connection.GetClient())
+		hotThreadsService := services.NewHotThreadsService(client)
+
+		report, err := util.ExecuteWithTimeout(func() (*models.HotThreadsReport, error) {
+			return hotThreadsService.GetHotThreads(ctx, hotThreadsNode, hotThreadsType, hotThreadsSnapshots)
+		})
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				fmt.Printf("Hot threads check failed: %s\n", constants.MsgTimeoutGeneric)
+			} else {
+				fmt.Printf("Hot threads check failed: %v\n", err)
+			}
+			return
+		}
+		if hotThreadsNode != "" && len(report.Nodes) == 0 {
+			fmt.Printf(constants.ErrNodeNotFound+"\n", hotThreadsNode)
+			return
+		}
+
+		if hotThreadsSave != "" {
+			if err := os.WriteFile(hotThreadsSave, []byte(report.Raw), 0644); err != nil {
+				fmt.Fprintln(os.Stderr, fmt.Errorf(constants.ErrHotThreadsSaveFailed, err))
+			} else {
+				fmt.Fprintf(os.Stderr, "Raw hot threads saved to %s\n", hotThreadsSave)
+			}
+		}
+
+		if core.OutputFormat().IsStructured() {
+			core.WriteOutput(output.KindHotThreads, report.Threads)
+			return
+		}
+		fmt.Print(ui.NewHotThreadsFormatter().FormatHotThreads(report))
+	},
+}
+
+func init() {
+	nodeCmd.AddCommand(nodeHotThreadsCmd)
+	nodeHotThreadsCmd.Flags().StringVar(&hotThreadsNode, "name", "", "Only sample this node (name, id or wildcard)")
+	nodeHotThreadsCmd.Flags().StringVar(&hotThreadsType, "thread-type", constants.HotThreadsTypeCPU, "Thread state to sample: cpu, wait or block")
+	nodeHotThreadsCmd.Flags().IntVar(&hotThreadsSnapshots, "snapshots", constants.DefaultHotThreadsSnapshots, "Number of stack snapshots taken per thread")
+	nodeHotThreadsCmd.Flags().StringVar(&hotThreadsSave, "save", "", "Also write the raw hot threads text to this file")
+}
@@ -0,0 +1,81 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/mertbahardogan/escope/internal/connection"
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui"
+	"github.com/mertbahardogan/escope/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var memoryNode string
+
+var nodeMemoryCmd = &cobra.Command{
+	Use:           "memory",
+	Short:         "Show circuit breakers and heap caches per node",
+	SilenceErrors: true,
+	Long: `Show the limit, estimated size and trip count of every circuit breaker, and the size,
+hit ratio and evictions of the fielddata, query and request caches of every node.
+
+Trip, hit and eviction counts are cumulative since the node started. Nodes whose parent
+breaker is above 85% of its limit are flagged; past the limit requests are rejected.
+
+Examples:
+  escope node memory
+  escope node memory --name data-node-1`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		client := elastic.NewClientWrapper(connection.GetClient())
+		memoryService := services.NewMemoryService(client)
+
+		infos, err := util.ExecuteWithTimeout(func() ([]models.NodeMemoryInfo, error) {
+			return memoryService.GetNodeMemoryInfo(ctx)
+		})
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				fmt.Printf("Node memory check failed: %s\n", constants.MsgTimeoutGeneric)
+			} else {
+				fmt.Printf("Node memory check failed: %v\n", err)
+			}
+			return
+		}
+
+		if memoryNode != "" {
+			filtered := make([]models.NodeMemoryInfo, 0, 1)
+			for _, info := range infos {
+				if info.Node == memoryNode {
+					filtered = append(filtered, info)
+				}
+			}
+			infos = filtered
+		}
+		if len(infos) == 0 {
+			if memoryNode != "" {
+				fmt.Printf(constants.ErrNodeNotFound+"\n", memoryNode)
+			} else {
+				fmt.Println(constants.MsgNoNodesFound)
+			}
+			return
+		}
+
+		if core.OutputFormat().IsStructured() {
+			core.WriteOutput(output.KindNodeMemory, infos)
+			return
+		}
+		fmt.Print(ui.NewMemoryFormatter().FormatNodeMemory(infos))
+	},
+}
+
+func init() {
+	nodeCmd.AddCommand(nodeMemoryCmd)
+	nodeMemoryCmd.Flags().StringVar(&memoryNode, "name", "", "Only show this node")
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/mertbahardogan/escope/internal/constants"
 	"github.com/mertbahardogan/escope/internal/elastic"
 	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
 	"github.com/mertbahardogan/escope/internal/services"
 	"github.com/mertbahardogan/escope/internal/ui"
 	"github.com/mertbahardogan/escope/internal/util"
@@ -36,7 +37,22 @@ var nodeCmd = &cobra.Command{
 			return
 		}
 
-		headers := []string{"Roles", "CPU%", "Mem%", "Heap%", "Disk%", "Free Disk", "Total Disk", "Docs", "Heap Used", "Heap Max", "IP", "Name"}
+		watermarks, err := util.ExecuteWithTimeout(func() (*models.DiskWatermarks, error) {
+			return nodeService.GetDiskWatermarks(context.Background())
+		})
+		if err != nil {
+			watermarks = models.DefaultDiskWatermarks()
+		}
+		for i := range nodes {
+			nodes[i].ApplyWatermarks(watermarks)
+		}
+
+		if core.OutputFormat().IsStructured() {
+			core.WriteOutput(output.KindNode, nodes)
+			return
+		}
+
+		headers := []string{"Roles", "CPU%", "Mem%", "Heap%", "Disk%", "Free Disk", "Total Disk", "Headroom", "Docs", "Heap Used", "Heap Max", "IP", "Name"}
 		rows := make([][]string, 0, len(nodes))
 
 		for _, node := range nodes {
@@ -102,6 +118,7 @@ var nodeCmd = &cobra.Command{
 				diskPercent,
 				node.DiskAvail,
 				diskTotal,
+				formatHeadroom(node),
 				docsStr,
 				heapUsed,
 				heapMax,
@@ -114,9 +131,35 @@ var nodeCmd = &cobra.Command{
 		formatter := ui.NewGenericTableFormatter()
 		fmt.Print(formatter.FormatTable(headers, rows))
 		fmt.Printf("Total: %d nodes\n", len(nodes))
+		fmt.Printf("Disk watermarks: %s\n", watermarks.Summary())
+		for _, node := range nodes {
+			if node.DiskWatermark == nil || !node.DiskWatermark.NearFloodStage {
+				continue
+			}
+			if node.DiskWatermark.Level == models.DiskLevelFloodStage {
+				fmt.Printf("Warning: "+constants.MsgDiskFloodStage+"\n", node.Name)
+			} else {
+				fmt.Printf("Warning: "+constants.MsgDiskNearFloodStage+"\n", node.Name, models.FormatBytes(node.DiskWatermark.FloodHeadroomBytes))
+			}
+		}
 	},
 }
 
+// formatHeadroom shows the free space a data node has left before the high watermark,
+// where shards start moving away from it
+func formatHeadroom(node models.NodeInfo) string {
+	if node.DiskWatermark == nil {
+		return constants.DashString
+	}
+	switch node.DiskWatermark.Level {
+	case models.DiskLevelFloodStage:
+		return "flood stage"
+	case models.DiskLevelHigh:
+		return "past high"
+	}
+	return models.FormatBytes(node.DiskWatermark.HighHeadroomBytes)
+}
+
 func init() {
 	core.RootCmd.AddCommand(nodeCmd)
 	nodeCmd.AddCommand(nodeDistCmd)
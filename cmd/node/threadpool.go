@@ -0,0 +1,133 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/mertbahardogan/escope/internal/connection"
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui"
+	"github.com/mertbahardogan/escope/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	threadPoolNode   string
+	threadPoolPools  string
+	threadPoolAll    bool
+	threadPoolSample time.Duration
+)
+
+var nodeThreadPoolCmd = &cobra.Command{
+	Use:           "threadpool",
+	Short:         "Show thread pool activity, queues and rejections per node",
+	SilenceErrors: true,
+	Long: `Show active threads, queue, rejected and completed counts of the thread pools of every node.
+
+Rejected and completed counts are cumulative since the node started. With --sample two
+snapshots are taken and the rejections and rates between them are shown, so a pool that is
+rejecting right now stands out from one that rejected last week.
+
+Examples:
+  escope node threadpool                        # Key pools plus any pool that queues or rejects
+  escope node threadpool --pool write,search    # Only these pools
+  escope node threadpool --name data-node-1 --all
+  escope node threadpool --sample 10s           # Rejection rates over 10 seconds`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		client := elastic.NewClientWrapper(connection.GetClient())
+		threadPoolService := services.NewThreadPoolService(client)
+
+		snapshot := func() (*models.ThreadPoolSnapshot, bool) {
+			s, err := util.ExecuteWithTimeout(func() (*models.ThreadPoolSnapshot, error) {
+				return threadPoolService.GetThreadPoolSnapshot(ctx)
+			})
+			if err != nil {
+				if errors.Is(err, context.DeadlineExceeded) {
+					fmt.Printf("Thread pool check failed: %s\n", constants.MsgTimeoutGeneric)
+				} else {
+					fmt.Printf("Thread pool check failed: %v\n", err)
+				}
+				return nil, false
+			}
+			return s, true
+		}
+
+		first, ok := snapshot()
+		if !ok {
+			return
+		}
+		pools := first.Pools
+		if threadPoolSample > 0 {
+			fmt.Fprintf(os.Stderr, "Sampling thread pools for %s...\n", threadPoolSample)
+			time.Sleep(threadPoolSample)
+			second, ok := snapshot()
+			if !ok {
+				return
+			}
+			pools = threadPoolService.CompareThreadPools(first, second)
+		}
+
+		pools = filterThreadPools(pools)
+		if len(pools) == 0 {
+			fmt.Println(constants.ErrThreadPoolNotFound)
+			return
+		}
+		if threadPoolSample > 0 {
+			sort.SliceStable(pools, func(i, j int) bool {
+				return pools[i].RejectedDelta > pools[j].RejectedDelta
+			})
+		}
+
+		if core.OutputFormat().IsStructured() {
+			core.WriteOutput(output.KindThreadPool, pools)
+			return
+		}
+		fmt.Print(ui.NewThreadPoolFormatter().FormatThreadPools(pools, threadPoolSample))
+	},
+}
+
+// filterThreadPools applies --name, --pool and --all
+func filterThreadPools(pools []models.ThreadPoolStats) []models.ThreadPoolStats {
+	wanted := make(map[string]bool)
+	for _, name := range strings.Split(threadPoolPools, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			wanted[name] = true
+		}
+	}
+
+	filtered := make([]models.ThreadPoolStats, 0, len(pools))
+	for _, p := range pools {
+		if threadPoolNode != "" && p.Node != threadPoolNode {
+			continue
+		}
+		switch {
+		case len(wanted) > 0:
+			if !wanted[p.Pool] {
+				continue
+			}
+		case !threadPoolAll && !services.IsKeyThreadPool(p):
+			continue
+		}
+		filtered = append(filtered, p)
+	}
+	return filtered
+}
+
+func init() {
+	nodeCmd.AddCommand(nodeThreadPoolCmd)
+	nodeThreadPoolCmd.Flags().StringVar(&threadPoolNode, "name", "", "Only show the pools of this node")
+	nodeThreadPoolCmd.Flags().StringVar(&threadPoolPools, "pool", "", "Comma-separated pools to show (e.g. write,search)")
+	nodeThreadPoolCmd.Flags().BoolVar(&threadPoolAll, "all", false, "Show every pool, including idle ones")
+	nodeThreadPoolCmd.Flags().DurationVar(&threadPoolSample, "sample", 0, "Take a second snapshot after this long and show rejection rates (e.g. 10s)")
+}
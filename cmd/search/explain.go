@@ -0,0 +1,91 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/mertbahardogan/escope/internal/connection"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/indexsession"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui"
+	"github.com/mertbahardogan/escope/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain",
+	Short: "Explain why a document matches a query or not",
+	Long: `Runs the explain API for one document and renders the Lucene explanation as an indented
+scoring breakdown: every line is a value and what it stands for, with the values it was
+computed from indented below it. For a document that does not match, the tree shows which
+clause failed.
+
+The query comes from --query (a JSON file, "-" reads stdin) or --q, as for 'escope search';
+only the query part of a search body is used.
+
+Examples:
+  escope search explain -n my-index --id 42 --query query.json
+  escope search explain -n my-index --id 42 --q 'title:elasticsearch'`,
+	SilenceErrors:      true,
+	DisableSuggestions: true,
+	Args:               cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		documentID, _ := cmd.Flags().GetString("id")
+		queryFile, _ := cmd.Flags().GetString("query")
+		queryString, _ := cmd.Flags().GetString("q")
+
+		name = indexsession.ResolveIndexName(name)
+		if name == "" || documentID == "" {
+			if name == "" {
+				indexsession.PrintIndexNameRequired()
+			} else {
+				fmt.Println("Error: --id is required")
+			}
+			fmt.Println("Usage: escope search explain [--name <index>] --id <document-id> (--query <file> | --q <query string>)")
+			return
+		}
+
+		body, err := services.LoadSearchBody(queryFile, queryString)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		data, err := services.QueryOnlyBody(body)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		runExplain(name, documentID, data)
+	},
+}
+
+func runExplain(indexName, documentID string, body []byte) {
+	client := elastic.NewClientWrapper(connection.GetClient())
+	searchService := services.NewSearchService(client)
+
+	explanation, err := util.ExecuteWithTimeout(func() (*models.QueryExplanation, error) {
+		return searchService.Explain(context.Background(), indexName, documentID, body)
+	})
+	if util.HandleServiceErrorWithReturn(err, "Query explain") {
+		return
+	}
+
+	if core.OutputFormat().IsStructured() {
+		core.WriteOutput(output.KindQueryExplain, explanation)
+		return
+	}
+	fmt.Print(ui.NewSearchFormatter().FormatQueryExplanation(explanation))
+}
+
+func init() {
+	searchCmd.AddCommand(explainCmd)
+	explainCmd.Flags().StringP("name", "n", "", "Index or alias (defaults to index from 'escope index use')")
+	explainCmd.Flags().String("id", "", "Document ID (required)")
+	explainCmd.Flags().String("query", "", "JSON file with the search body or a query (\"-\" reads stdin)")
+	explainCmd.Flags().String("q", "", "Query in Lucene query_string syntax")
+}
@@ -0,0 +1,108 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/mertbahardogan/escope/internal/connection"
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/indexsession"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui"
+	"github.com/mertbahardogan/escope/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Run a search and summarise hits, shard failures and timings",
+	Long: `Runs a search and shows the hit count, took, timed_out and shard failures, followed by
+the top hits. --fields projects each hit to the given _source fields (dotted paths work).
+
+The request comes from --query, a JSON file ("-" reads stdin) holding either a full search
+body or just a query, or from --q in Lucene query_string syntax. Without either every
+document matches.
+
+--profile adds the search profile: for every shard a timing tree of the query components,
+collectors and aggregations, slowest shard first, with the breakdown entries where each
+query spent most of its time.
+
+Examples:
+  escope search -n my-index --q 'status:500 AND host:web-1' --fields @timestamp,message
+  escope search -n logs-* --query slow-query.json --profile
+  cat query.json | escope search -n my-index --query - --size 3`,
+	SilenceErrors:      true,
+	DisableSuggestions: true,
+	Args:               cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		queryFile, _ := cmd.Flags().GetString("query")
+		queryString, _ := cmd.Flags().GetString("q")
+		size, _ := cmd.Flags().GetInt("size")
+		fieldsFlag, _ := cmd.Flags().GetString("fields")
+		profile, _ := cmd.Flags().GetBool("profile")
+
+		name = indexsession.ResolveIndexName(name)
+		if name == "" {
+			indexsession.PrintIndexNameRequired()
+			return
+		}
+
+		body, err := services.LoadSearchBody(queryFile, queryString)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		// A size in the request file wins unless --size is given
+		if _, ok := body["size"]; ok && !cmd.Flags().Changed("size") {
+			size = -1
+		}
+
+		var fields []string
+		for _, field := range strings.Split(fieldsFlag, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+
+		data, err := services.BuildSearchBody(body, size, fields, profile)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		runSearch(name, data, fields)
+	},
+}
+
+func runSearch(indexName string, body []byte, fields []string) {
+	client := elastic.NewClientWrapper(connection.GetClient())
+	searchService := services.NewSearchService(client)
+
+	result, err := util.ExecuteWithTimeout(func() (*models.SearchResult, error) {
+		return searchService.Search(context.Background(), indexName, body, fields)
+	})
+	if util.HandleServiceErrorWithReturn(err, "Search") {
+		return
+	}
+
+	if core.OutputFormat().IsStructured() {
+		core.WriteOutput(output.KindSearch, result)
+		return
+	}
+	fmt.Print(ui.NewSearchFormatter().FormatSearchResult(result, fields))
+}
+
+func init() {
+	searchCmd.Flags().StringP("name", "n", "", "Index, alias or pattern (defaults to index from 'escope index use')")
+	searchCmd.Flags().String("query", "", "JSON file with the search body or a query (\"-\" reads stdin)")
+	searchCmd.Flags().String("q", "", "Query in Lucene query_string syntax")
+	searchCmd.Flags().Int("size", constants.DefaultSearchSize, "Number of hits to return")
+	searchCmd.Flags().String("fields", "", "_source fields shown per hit (comma-separated)")
+	searchCmd.Flags().Bool("profile", false, "Profile the search and show per-shard timings")
+	core.RootCmd.AddCommand(searchCmd)
+}
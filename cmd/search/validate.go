@@ -0,0 +1,84 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/mertbahardogan/escope/internal/connection"
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/indexsession"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui"
+	"github.com/mertbahardogan/escope/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate a query and show the Lucene query it is rewritten to",
+	Long: `Runs _validate/query with explain and rewrite. A valid query is shown as the Lucene query
+each index rewrites it to, which reveals how analyzers, multi_match and wildcards expand.
+An invalid query shows the parse error.
+
+The query comes from --query (a JSON file, "-" reads stdin) or --q, as for 'escope search'.
+Exits with code 2 when the query is invalid and 1 when validation could not run.
+
+Examples:
+  escope search validate -n my-index --query query.json
+  escope search validate -n logs-* --q 'message:"connection reset" AND status:[500 TO 599]'`,
+	SilenceErrors:      true,
+	DisableSuggestions: true,
+	Args:               cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		queryFile, _ := cmd.Flags().GetString("query")
+		queryString, _ := cmd.Flags().GetString("q")
+
+		name = indexsession.ResolveIndexName(name)
+		if name == "" {
+			indexsession.PrintIndexNameRequired()
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+
+		body, err := services.LoadSearchBody(queryFile, queryString)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+		data, err := services.QueryOnlyBody(body)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+
+		client := elastic.NewClientWrapper(connection.GetClient())
+		searchService := services.NewSearchService(client)
+		validation, err := util.ExecuteWithTimeout(func() (*models.QueryValidation, error) {
+			return searchService.Validate(context.Background(), name, data)
+		})
+		if util.HandleServiceErrorWithReturn(err, "Query validation") {
+			return &core.ExitError{Code: constants.ExitCodeCheckIncomplete}
+		}
+
+		if core.OutputFormat().IsStructured() {
+			core.WriteOutput(output.KindQueryValidation, validation)
+		} else {
+			fmt.Print(ui.NewSearchFormatter().FormatQueryValidation(validation))
+		}
+		if !validation.Valid {
+			return &core.ExitError{Code: constants.ExitCodeInvalidQuery}
+		}
+		return nil
+	},
+}
+
+func init() {
+	searchCmd.AddCommand(validateCmd)
+	validateCmd.Flags().StringP("name", "n", "", "Index, alias or pattern (defaults to index from 'escope index use')")
+	validateCmd.Flags().String("query", "", "JSON file with the search body or a query (\"-\" reads stdin)")
+	validateCmd.Flags().String("q", "", "Query in Lucene query_string syntax")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/mertbahardogan/escope/internal/constants"
 	"github.com/mertbahardogan/escope/internal/elastic"
 	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
 	"github.com/mertbahardogan/escope/internal/services"
 	"github.com/mertbahardogan/escope/internal/ui"
 	internalUtil "github.com/mertbahardogan/escope/internal/util"
@@ -44,6 +45,14 @@ var segmentsCmd = &cobra.Command{
 			}
 		}
 
+		if core.OutputFormat().IsStructured() {
+			sort.Slice(filteredSegments, func(i, j int) bool {
+				return filteredSegments[i].SegmentCount > filteredSegments[j].SegmentCount
+			})
+			core.WriteOutput(output.KindSegments, filteredSegments)
+			return
+		}
+
 		if len(filteredSegments) == 0 {
 			fmt.Println("No indices found with segments")
 			return
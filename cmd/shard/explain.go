@@ -0,0 +1,64 @@
+package shard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/mertbahardogan/escope/internal/connection"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui"
+	"github.com/mertbahardogan/escope/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain",
+	Short: "Explain why a shard is unassigned (first unassigned shard by default)",
+	Long: `Explain shard allocation with the cluster allocation explain API.
+
+Without flags the first unassigned shard the cluster finds is explained. Use --index,
+--shard and --primary to explain a specific shard copy, assigned or not.`,
+	SilenceErrors:      true,
+	DisableSuggestions: true,
+	Args:               cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		index, _ := cmd.Flags().GetString("index")
+		shardNum, _ := cmd.Flags().GetInt("shard")
+		primary, _ := cmd.Flags().GetBool("primary")
+
+		var req *models.AllocationExplainRequest
+		if index != "" {
+			req = &models.AllocationExplainRequest{Index: index, Shard: shardNum, Primary: primary}
+		} else if cmd.Flags().Changed("shard") || primary {
+			fmt.Println("Error: --shard and --primary need --index")
+			return
+		}
+
+		client := elastic.NewClientWrapper(connection.GetClient())
+		shardService := services.NewShardService(client)
+
+		explain, err := util.ExecuteWithTimeout(func() (*models.AllocationExplain, error) {
+			return shardService.ExplainAllocation(context.Background(), req)
+		})
+		if util.HandleServiceErrorWithReturn(err, "Allocation explain") {
+			return
+		}
+
+		if core.OutputFormat().IsStructured() {
+			core.WriteOutput(output.KindShardExplain, explain)
+			return
+		}
+		fmt.Print(ui.NewAllocationExplainFormatter().FormatAllocationExplain(explain))
+	},
+}
+
+func init() {
+	explainCmd.Flags().String("index", "", "Index of the shard to explain")
+	explainCmd.Flags().Int("shard", 0, "Shard number to explain (with --index)")
+	explainCmd.Flags().Bool("primary", false, "Explain the primary copy instead of a replica (with --index)")
+	shardCmd.AddCommand(explainCmd)
+}
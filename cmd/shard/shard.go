@@ -11,6 +11,7 @@ import (
 	"github.com/mertbahardogan/escope/internal/constants"
 	"github.com/mertbahardogan/escope/internal/elastic"
 	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/output"
 	"github.com/mertbahardogan/escope/internal/services"
 	"github.com/mertbahardogan/escope/internal/ui"
 	"github.com/mertbahardogan/escope/internal/util"
@@ -47,6 +48,11 @@ var shardCmd = &cobra.Command{
 			}
 		}
 
+		if core.OutputFormat().IsStructured() {
+			core.WriteOutput(output.KindShard, filteredShards)
+			return
+		}
+
 		headers := []string{"Shard", "Type", "State", "Size", "Node IP", "Index"}
 		rows := make([][]string, 0, len(filteredShards))
 
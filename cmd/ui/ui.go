@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/mertbahardogan/escope/internal/config"
+	"github.com/mertbahardogan/escope/internal/connection"
+	"github.com/mertbahardogan/escope/internal/elastic"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui/tui"
+	"github.com/spf13/cobra"
+)
+
+var uiCmd = &cobra.Command{
+	Use:           "ui",
+	Short:         "Interactive dashboard for cluster, nodes, indices, shards, segments and GC",
+	SilenceErrors: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		interval, _ := cmd.Flags().GetDuration("interval")
+		if interval < time.Second {
+			fmt.Println("Error: --interval must be at least 1s")
+			return
+		}
+
+		client := elastic.NewClientWrapper(connection.GetClient())
+		svc := tui.DashboardServices{
+			Cluster:  services.NewClusterService(client),
+			Node:     services.NewNodeService(client),
+			Index:    services.NewIndexService(client),
+			Shard:    services.NewShardService(client),
+			Segments: services.NewSegmentsService(client),
+			GC:       services.NewGCService(client),
+		}
+
+		host, _ := config.GetActiveHost()
+		if err := tui.RunDashboard(svc, host, interval); err != nil {
+			fmt.Printf("Error running dashboard: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	uiCmd.Flags().Duration("interval", 5*time.Second, "Auto refresh interval")
+	core.RootCmd.AddCommand(uiCmd)
+}
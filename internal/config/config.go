@@ -8,11 +8,29 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// ConnectionConfig is one saved alias. Host is the primary node and also keys the
+// host sessions; Addresses lists further nodes of the same cluster used for failover.
 type ConnectionConfig struct {
-	Host     string `yaml:"host"`
-	Username string `yaml:"username"`
-	Password string `yaml:"password"`
-	Secure   bool   `yaml:"secure"`
+	Host        string   `yaml:"host"`
+	Addresses   []string `yaml:"addresses,omitempty"`
+	Sniff       bool     `yaml:"sniff,omitempty"`
+	CloudID     string   `yaml:"cloud_id,omitempty"`
+	Type        string   `yaml:"type,omitempty"`
+	AWSRegion   string   `yaml:"aws_region,omitempty"`
+	AWSProfile  string   `yaml:"aws_profile,omitempty"`
+	AWSService  string   `yaml:"aws_service,omitempty"`
+	Username    string   `yaml:"username"`
+	Password    string   `yaml:"password"`
+	Secure      bool     `yaml:"secure"`
+	RulesFile   string   `yaml:"rules_file,omitempty"`
+	APIKey      string   `yaml:"api_key,omitempty"`
+	BearerToken string   `yaml:"bearer_token,omitempty"`
+
+	CACert             string `yaml:"ca_cert,omitempty"`
+	ClientCert         string `yaml:"client_cert,omitempty"`
+	ClientKey          string `yaml:"client_key,omitempty"`
+	CAFingerprint      string `yaml:"ca_fingerprint,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"`
 }
 
 type AppConfig struct {
@@ -22,6 +40,7 @@ type AppConfig struct {
 
 type HostConfig struct {
 	Config     AppConfig                   `yaml:"config"`
+	Encryption *EncryptionConfig           `yaml:"encryption,omitempty"`
 	Hosts      map[string]ConnectionConfig `yaml:"hosts"`
 	ActiveHost string                      `yaml:"active_host,omitempty"`
 	Sessions   map[string]HostSessionData  `yaml:"sessions,omitempty"`
@@ -32,12 +51,18 @@ func configFilePath() string {
 	return filepath.Join(home, constants.ConfigFilePath)
 }
 
+// Save writes the config file readable by the owner only, since it holds credentials
 func Save(cfg HostConfig) error {
-	f, err := os.Create(configFilePath())
+	path := configFilePath()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, constants.ConfigFileMode)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
+	// OpenFile keeps the mode of an existing file, tighten files written by older versions
+	if err := f.Chmod(constants.ConfigFileMode); err != nil {
+		return err
+	}
 	enc := yaml.NewEncoder(f)
 	return enc.Encode(cfg)
 }
@@ -86,6 +111,15 @@ func SaveHost(alias string, connCfg ConnectionConfig) error {
 	if len(hostCfg.Hosts) == 0 {
 		hostCfg.ActiveHost = alias
 	}
+	if hostCfg.Encryption != nil {
+		key, err := unlock(hostCfg.Encryption)
+		if err != nil {
+			return err
+		}
+		if _, err := encryptSecrets(key, &connCfg); err != nil {
+			return err
+		}
+	}
 	hostCfg.Hosts[alias] = connCfg
 	return Save(hostCfg)
 }
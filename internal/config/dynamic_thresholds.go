@@ -2,7 +2,9 @@ package config
 
 import (
 	"context"
+	"github.com/mertbahardogan/escope/internal/constants"
 	"github.com/mertbahardogan/escope/internal/interfaces"
+	"github.com/mertbahardogan/escope/internal/models"
 )
 
 type DynamicThresholds struct {
@@ -42,12 +44,22 @@ func CalculateDynamicThresholds(ctx context.Context, client interfaces.ElasticCl
 		HighCPUThreshold:    calculateCPUThreshold(nodeCount),
 		HighMemoryThreshold: calculateMemoryThreshold(nodeCount),
 		HighHeapThreshold:   calculateHeapThreshold(nodeCount),
-		HighDiskThreshold:   90.0, // Static for now
+		HighDiskThreshold:   calculateDiskThreshold(ctx, client),
 	}
 
 	return thresholds, nil
 }
 
+// calculateDiskThreshold follows the cluster's high disk watermark, where shards start
+// moving off a node. Absolute watermarks and unreadable settings keep the 90% default.
+func calculateDiskThreshold(ctx context.Context, client interfaces.ElasticClient) float64 {
+	settings, err := client.GetClusterSettings(ctx)
+	if err != nil {
+		return constants.HighDiskThreshold
+	}
+	return models.DiskWatermarksFromSettings(settings).HighPercent(constants.HighDiskThreshold)
+}
+
 // calculateSegmentThreshold scales segment threshold based on cluster size
 func calculateSegmentThreshold(nodeCount int) int {
 	// Base threshold: 1000 segments
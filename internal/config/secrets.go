@@ -0,0 +1,314 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+// Secret value forms accepted for password, api_key and bearer_token
+const (
+	SecretEnvPrefix  = "env:"  // env:ES_PASS reads the environment variable
+	SecretFilePrefix = "file:" // file:/run/secrets/es reads the file, trailing newline trimmed
+	encryptedPrefix  = "enc:"  // written by 'escope config encrypt'
+)
+
+const (
+	kdfScrypt      = "scrypt"
+	scryptN        = 1 << 15
+	scryptR        = 8
+	scryptP        = 1
+	keyLength      = 32
+	saltLength     = 16
+	checkPlaintext = "escope"
+)
+
+// EncryptionConfig is stored in the config file once secrets are encrypted.
+// Check holds a known value encrypted with the key, so a wrong passphrase fails early.
+type EncryptionConfig struct {
+	KDF   string `yaml:"kdf"`
+	Salt  string `yaml:"salt"`
+	Check string `yaml:"check"`
+}
+
+// derived keys are cached per salt so the passphrase is asked at most once per run
+var keyCache = make(map[string][]byte)
+
+// secretFields returns pointers to every secret held by a connection config
+func secretFields(c *ConnectionConfig) []*string {
+	return []*string{&c.Password, &c.APIKey, &c.BearerToken}
+}
+
+// IsSecretReference reports whether value points to an environment variable or a file
+func IsSecretReference(value string) bool {
+	return strings.HasPrefix(value, SecretEnvPrefix) || strings.HasPrefix(value, SecretFilePrefix)
+}
+
+func isEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// ResolveSecrets returns cfg with env:/file: references and encrypted values replaced by
+// their plaintext. The passphrase is only requested when an encrypted value is present.
+func ResolveSecrets(cfg ConnectionConfig) (ConnectionConfig, error) {
+	var key []byte
+	for _, field := range secretFields(&cfg) {
+		value := *field
+		switch {
+		case strings.HasPrefix(value, SecretEnvPrefix):
+			name := strings.TrimPrefix(value, SecretEnvPrefix)
+			resolved, ok := os.LookupEnv(name)
+			if !ok {
+				return cfg, fmt.Errorf(constants.ErrSecretEnvNotSet, name)
+			}
+			*field = resolved
+		case strings.HasPrefix(value, SecretFilePrefix):
+			path := strings.TrimPrefix(value, SecretFilePrefix)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return cfg, fmt.Errorf(constants.ErrSecretFileRead, path, err)
+			}
+			*field = strings.TrimRight(string(data), "\r\n")
+		case isEncrypted(value):
+			if key == nil {
+				hostCfg, err := Load()
+				if err != nil {
+					return cfg, err
+				}
+				if key, err = unlock(hostCfg.Encryption); err != nil {
+					return cfg, err
+				}
+			}
+			plain, err := decryptValue(key, value)
+			if err != nil {
+				return cfg, err
+			}
+			*field = plain
+		}
+	}
+	return cfg, nil
+}
+
+// IsEncrypted reports whether the config file has encryption enabled
+func IsEncrypted() (bool, error) {
+	hostCfg, err := Load()
+	if err != nil {
+		return false, err
+	}
+	return hostCfg.Encryption != nil, nil
+}
+
+// Encrypt enables encryption for the config file and encrypts every literal secret.
+// References (env:, file:) are left as they are. Returns the number of values encrypted.
+func Encrypt(passphrase string) (int, error) {
+	hostCfg, err := Load()
+	if err != nil {
+		return 0, err
+	}
+	if hostCfg.Encryption != nil {
+		return 0, errors.New(constants.ErrConfigAlreadyEncrypted)
+	}
+	if passphrase == "" {
+		return 0, errors.New(constants.ErrPassphraseEmpty)
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return 0, err
+	}
+	enc := &EncryptionConfig{KDF: kdfScrypt, Salt: base64.StdEncoding.EncodeToString(salt)}
+	key, err := deriveKey(passphrase, enc.Salt)
+	if err != nil {
+		return 0, err
+	}
+	if enc.Check, err = encryptValue(key, checkPlaintext); err != nil {
+		return 0, err
+	}
+	hostCfg.Encryption = enc
+
+	count := 0
+	for alias, conn := range hostCfg.Hosts {
+		n, err := encryptSecrets(key, &conn)
+		if err != nil {
+			return 0, err
+		}
+		count += n
+		hostCfg.Hosts[alias] = conn
+	}
+	if err := Save(hostCfg); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Decrypt writes every encrypted secret back as plaintext and disables encryption.
+// Returns the number of values decrypted.
+func Decrypt() (int, error) {
+	hostCfg, err := Load()
+	if err != nil {
+		return 0, err
+	}
+	if hostCfg.Encryption == nil {
+		return 0, errors.New(constants.ErrConfigNotEncrypted)
+	}
+	key, err := unlock(hostCfg.Encryption)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for alias, conn := range hostCfg.Hosts {
+		for _, field := range secretFields(&conn) {
+			if !isEncrypted(*field) {
+				continue
+			}
+			plain, err := decryptValue(key, *field)
+			if err != nil {
+				return 0, err
+			}
+			*field = plain
+			count++
+		}
+		hostCfg.Hosts[alias] = conn
+	}
+	hostCfg.Encryption = nil
+	if err := Save(hostCfg); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// encryptSecrets encrypts the literal secrets of conn in place
+func encryptSecrets(key []byte, conn *ConnectionConfig) (int, error) {
+	count := 0
+	for _, field := range secretFields(conn) {
+		if *field == "" || isEncrypted(*field) || IsSecretReference(*field) {
+			continue
+		}
+		value, err := encryptValue(key, *field)
+		if err != nil {
+			return 0, err
+		}
+		*field = value
+		count++
+	}
+	return count, nil
+}
+
+// unlock derives the key for enc from the passphrase and checks it against enc.Check
+func unlock(enc *EncryptionConfig) ([]byte, error) {
+	if enc == nil {
+		return nil, errors.New(constants.ErrConfigNotEncrypted)
+	}
+	if enc.KDF != kdfScrypt {
+		return nil, fmt.Errorf(constants.ErrUnsupportedKDF, enc.KDF)
+	}
+	if key, ok := keyCache[enc.Salt]; ok {
+		return key, nil
+	}
+
+	passphrase, err := ReadPassphrase(false)
+	if err != nil {
+		return nil, err
+	}
+	key, err := deriveKey(passphrase, enc.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if check, err := decryptValue(key, enc.Check); err != nil || check != checkPlaintext {
+		return nil, errors.New(constants.ErrWrongPassphrase)
+	}
+	keyCache[enc.Salt] = key
+	return key, nil
+}
+
+// ReadPassphrase returns ESCOPE_PASSPHRASE when set, otherwise prompts on the terminal.
+// With confirm the passphrase has to be typed twice.
+func ReadPassphrase(confirm bool) (string, error) {
+	if passphrase, ok := os.LookupEnv(constants.PassphraseEnvVar); ok {
+		return passphrase, nil
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf(constants.ErrPassphraseRequired, constants.PassphraseEnvVar)
+	}
+
+	fmt.Fprint(os.Stderr, constants.MsgPassphrasePrompt)
+	first, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	if confirm {
+		fmt.Fprint(os.Stderr, constants.MsgPassphraseConfirm)
+		second, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		if string(first) != string(second) {
+			return "", errors.New(constants.ErrPassphraseMismatch)
+		}
+	}
+	return string(first), nil
+}
+
+func deriveKey(passphrase, encodedSalt string) ([]byte, error) {
+	salt, err := base64.StdEncoding.DecodeString(encodedSalt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption salt: %w", err)
+	}
+	return scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keyLength)
+}
+
+// encryptValue seals plaintext with AES-256-GCM; the random nonce is prepended to the ciphertext
+func encryptValue(key []byte, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func decryptValue(key []byte, value string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil {
+		return "", fmt.Errorf(constants.ErrSecretDecryptFailed, err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", fmt.Errorf(constants.ErrSecretDecryptFailed, errors.New("value too short"))
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf(constants.ErrSecretDecryptFailed, err)
+	}
+	return string(plain), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
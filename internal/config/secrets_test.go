@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+)
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	t.Setenv(constants.PassphraseEnvVar, "correct horse")
+	t.Setenv("ES_TOKEN", "from-env")
+
+	if err := SaveHost("prod", ConnectionConfig{Host: "http://localhost:9200", Username: "elastic", Password: "s3cret", BearerToken: "env:ES_TOKEN"}); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filepath.Join(tmp, constants.ConfigFilePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != constants.ConfigFileMode {
+		t.Fatalf("mode %v", info.Mode().Perm())
+	}
+
+	count, err := Encrypt("correct horse")
+	if err != nil || count != 1 {
+		t.Fatalf("encrypt: %d %v", count, err)
+	}
+	raw, _ := os.ReadFile(filepath.Join(tmp, constants.ConfigFilePath))
+	if strings.Contains(string(raw), "s3cret") || !strings.Contains(string(raw), "env:ES_TOKEN") {
+		t.Fatalf("config file content:\n%s", raw)
+	}
+
+	saved, err := LoadHost("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := ResolveSecrets(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.Password != "s3cret" || resolved.BearerToken != "from-env" {
+		t.Fatalf("resolved: %+v", resolved)
+	}
+
+	keyCache = make(map[string][]byte)
+	t.Setenv(constants.PassphraseEnvVar, "wrong")
+	if _, err := ResolveSecrets(saved); err == nil || err.Error() != constants.ErrWrongPassphrase {
+		t.Fatalf("expected wrong passphrase, got %v", err)
+	}
+
+	t.Setenv(constants.PassphraseEnvVar, "correct horse")
+	if count, err := Decrypt(); err != nil || count != 1 {
+		t.Fatalf("decrypt: %d %v", count, err)
+	}
+	if saved, _ := LoadHost("prod"); saved.Password != "s3cret" {
+		t.Fatalf("password after decrypt: %q", saved.Password)
+	}
+}
+
+func TestResolveSecretFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "es")
+	if err := os.WriteFile(path, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := ResolveSecrets(ConnectionConfig{Password: SecretFilePrefix + path, APIKey: "env:ESCOPE_TEST_UNSET"})
+	if err == nil {
+		t.Fatalf("expected unset env error, got %+v", resolved)
+	}
+	resolved, err = ResolveSecrets(ConnectionConfig{Password: SecretFilePrefix + path})
+	if err != nil || resolved.Password != "from-file" {
+		t.Fatalf("file secret: %q %v", resolved.Password, err)
+	}
+}
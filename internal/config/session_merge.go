@@ -3,7 +3,12 @@ package config
 import "strings"
 
 // CanonicalSessionHostKey normalizes the URL used as the primary sessions map key.
+// A multi-host alias is keyed by its primary host, so a comma-separated host list
+// maps to the same sessions as its first entry.
 func CanonicalSessionHostKey(h string) string {
+	if i := strings.Index(h, ","); i >= 0 {
+		h = h[:i]
+	}
 	h = strings.TrimSpace(h)
 	for strings.HasSuffix(h, "/") {
 		h = strings.TrimSuffix(h, "/")
@@ -57,3 +57,12 @@ func TestPutHostSessionRoundTrip(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestCanonicalSessionHostKeyMultiHost(t *testing.T) {
+	want := "https://es-1:9200"
+	for _, h := range []string{"https://es-1:9200/", " https://es-1:9200 ,https://es-2:9200", "https://es-1:9200/,https://es-2:9200"} {
+		if got := CanonicalSessionHostKey(h); got != want {
+			t.Fatalf("%q: got %q", h, got)
+		}
+	}
+}
@@ -2,39 +2,142 @@ package connection
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/mertbahardogan/escope/internal/config"
+	"github.com/mertbahardogan/escope/internal/constants"
 	"github.com/mertbahardogan/escope/internal/elastic"
 )
 
 type Config struct {
-	Host     string
-	Username string
-	Password string
-	Secure   bool
+	Host        string
+	Addresses   []string
+	Sniff       bool
+	CloudID     string
+	Type        string
+	AWSRegion   string
+	AWSProfile  string
+	AWSService  string
+	Username    string
+	Password    string
+	Secure      bool
+	RulesFile   string
+	APIKey      string
+	BearerToken string
+
+	CACert             string
+	ClientCert         string
+	ClientKey          string
+	CAFingerprint      string
+	InsecureSkipVerify bool
+}
+
+// FromSavedConfig maps a saved host entry to the connection settings used to build clients
+func FromSavedConfig(c config.ConnectionConfig) Config {
+	return Config{
+		Host:               c.Host,
+		Addresses:          c.Addresses,
+		Sniff:              c.Sniff,
+		CloudID:            c.CloudID,
+		Type:               c.Type,
+		AWSRegion:          c.AWSRegion,
+		AWSProfile:         c.AWSProfile,
+		AWSService:         c.AWSService,
+		Username:           c.Username,
+		Password:           c.Password,
+		Secure:             c.Secure,
+		RulesFile:          c.RulesFile,
+		APIKey:             c.APIKey,
+		BearerToken:        c.BearerToken,
+		CACert:             c.CACert,
+		ClientCert:         c.ClientCert,
+		ClientKey:          c.ClientKey,
+		CAFingerprint:      c.CAFingerprint,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+}
+
+func (c Config) clientOptions() elastic.ClientOptions {
+	return elastic.ClientOptions{
+		Host:               c.Host,
+		Addresses:          c.Addresses,
+		Sniff:              c.Sniff,
+		CloudID:            c.CloudID,
+		Type:               c.Type,
+		AWSRegion:          c.AWSRegion,
+		AWSProfile:         c.AWSProfile,
+		AWSService:         c.AWSService,
+		Username:           c.Username,
+		Password:           c.Password,
+		APIKey:             c.APIKey,
+		BearerToken:        c.BearerToken,
+		CACert:             c.CACert,
+		ClientCert:         c.ClientCert,
+		ClientKey:          c.ClientKey,
+		CAFingerprint:      c.CAFingerprint,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
 }
 
 var (
-	once   sync.Once
-	client *elasticsearch.Client
-	conf   Config
+	once    sync.Once
+	client  *elasticsearch.Client
+	conf    Config
+	tracker *elastic.NodeTracker
 )
 
 func SetConfig(c Config) {
 	conf = c
 	once = sync.Once{}
 	client = nil
+	tracker = nil
+}
+
+// ServedBy returns the node that answered the last request of the shared client, and
+// the nodes that failed before it. Both are empty until a request was made.
+func ServedBy() (string, []string) {
+	if tracker == nil {
+		return "", nil
+	}
+	return tracker.ServedBy(), tracker.FailedNodes()
+}
+
+// IsMultiNode reports whether the active connection can be served by more than one node,
+// either through failover addresses or sniffing
+func IsMultiNode() bool {
+	return len(conf.Addresses) > 0 || conf.Sniff
+}
+
+// SplitHostList splits a comma-separated --host value into the primary host and the
+// additional addresses
+func SplitHostList(value string) (string, []string) {
+	var hosts []string
+	for _, h := range strings.Split(value, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			hosts = append(hosts, h)
+		}
+	}
+	if len(hosts) == 0 {
+		return "", nil
+	}
+	return hosts[0], hosts[1:]
 }
 
 func CurrentHost() string {
 	return conf.Host
 }
 
+// CurrentRulesFile returns the check rules file saved with the active host, if any
+func CurrentRulesFile() string {
+	return conf.RulesFile
+}
+
 func SessionHostURL() (string, bool) {
 	if h := strings.TrimSpace(CurrentHost()); h != "" {
 		return h, true
@@ -58,23 +161,37 @@ func ClearConfig() {
 	conf = Config{}
 	once = sync.Once{}
 	client = nil
+	tracker = nil
 }
 
 func LoadConfigFromFile(alias string) error {
-	cfg, err := config.LoadHost(alias)
+	cfg, err := LoadSavedConfig(alias)
 	if err != nil {
 		return err
 	}
-	SetConfig(Config(cfg))
+	SetConfig(cfg)
 	return nil
 }
 
 func GetSavedConfig(alias string) Config {
-	cfg, err := config.LoadHost(alias)
+	cfg, err := LoadSavedConfig(alias)
 	if err != nil {
 		return Config{}
 	}
-	return Config(cfg)
+	return cfg
+}
+
+// LoadSavedConfig loads a saved host with env:/file: references and encrypted secrets resolved
+func LoadSavedConfig(alias string) (Config, error) {
+	cfg, err := config.LoadHost(alias)
+	if err != nil {
+		return Config{}, err
+	}
+	resolved, err := config.ResolveSecrets(cfg)
+	if err != nil {
+		return Config{}, fmt.Errorf(constants.ErrSecretResolveFailed, alias, err)
+	}
+	return FromSavedConfig(resolved), nil
 }
 
 func ListSavedConfigs() ([]string, error) {
@@ -99,17 +216,38 @@ func GetClient() *elasticsearch.Client {
 	}
 
 	once.Do(func() {
-		client = elastic.NewClient(conf.Host, conf.Username, conf.Password, conf.Secure)
+		tracker = elastic.NewNodeTracker()
+		opts := conf.clientOptions()
+		opts.Tracker = tracker
+		client = elastic.NewClient(opts)
 	})
 	return client
 }
 
+// NewClientForHost builds a separate client for a saved host, leaving the shared client of
+// the active host untouched
+func NewClientForHost(alias string) (*elasticsearch.Client, error) {
+	cfg, err := LoadSavedConfig(alias)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if cfg.Host == "" {
+		return nil, fmt.Errorf(constants.ErrHostNotFound, alias)
+	}
+	return elastic.NewClient(cfg.clientOptions()), nil
+}
+
+// CheckTLS verifies the TLS settings of cfg with a handshake against its host
+func CheckTLS(cfg Config, timeoutSeconds int) error {
+	return elastic.CheckTLS(cfg.clientOptions(), time.Duration(timeoutSeconds)*time.Second)
+}
+
 func TestConnection(cfg Config, timeoutSeconds int) error {
 	if cfg.Host == "" {
 		return fmt.Errorf("host is required")
 	}
 
-	tempClient := elastic.NewClient(cfg.Host, cfg.Username, cfg.Password, cfg.Secure)
+	tempClient := elastic.NewClient(cfg.clientOptions())
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutSeconds)*time.Second)
 	defer cancel()
@@ -10,6 +10,21 @@ const (
 
 	SeverityCritical = "CRITICAL"
 	SeverityWarning  = "WARNING"
+	SeverityOK       = "OK"
+
+	// escope check --fail-on gates and the exit codes they produce
+	FailOnWarning           = "warning"
+	FailOnCritical          = "critical"
+	ExitCodeCheckIncomplete = 1
+	ExitCodeWarning         = 2
+	ExitCodeCritical        = 3
+
+	// escope index settings diff/verify --exit-code: settings differ from the other index or
+	// the desired state; errors exit with ExitCodeCheckIncomplete
+	ExitCodeDrift = 2
+
+	// escope search validate: the query does not parse; errors exit with ExitCodeCheckIncomplete
+	ExitCodeInvalidQuery = 2
 
 	HealthField    = "health"
 	StatusField    = "status"
@@ -103,6 +118,30 @@ const (
 	DocsField            = "docs"
 	StoreFieldKey        = "store"
 
+	// Thread pool field keys of nodes stats
+	ThreadPoolField = "thread_pool"
+	ThreadsField    = "threads"
+	ActiveField     = "active"
+	QueueField      = "queue"
+	LargestField    = "largest"
+	RejectedField   = "rejected"
+	CompletedField  = "completed"
+
+	// Circuit breaker and cache field keys of nodes stats
+	BreakersField             = "breakers"
+	LimitSizeInBytesField     = "limit_size_in_bytes"
+	EstimatedSizeInBytesField = "estimated_size_in_bytes"
+	OverheadField             = "overhead"
+	TrippedField              = "tripped"
+	FielddataField            = "fielddata"
+	QueryCacheField           = "query_cache"
+	RequestCacheField         = "request_cache"
+	MemorySizeInBytesField    = "memory_size_in_bytes"
+	HitCountField             = "hit_count"
+	MissCountField            = "miss_count"
+	EvictionsField            = "evictions"
+	ParentBreaker             = "parent"
+
 	// Shard field keys
 	NodeFieldKey = "node"
 	IPFieldKey   = "ip"
@@ -132,6 +171,41 @@ const (
 	LowMemoryPressure     = 60
 	MediumMemoryPressure  = 80
 
+	// Unassigned shard copies explained by check; each costs one allocation explain call
+	MaxExplainedUnassignedShards = 10
+	// Allocation explain calls check runs at a time
+	UnassignedExplainConcurrency = 4
+
+	// Window escope check --sample-thread-pools waits between two thread pool snapshots to spot growing rejections
+	ThreadPoolCheckSampleSeconds = 2
+
+	// Parent circuit breaker usage (estimated / limit) flagged by escope check
+	ParentBreakerWarningPercent  = 85.0
+	ParentBreakerCriticalPercent = 95.0
+
+	// Hot threads sampling: snapshots per thread and stack frames kept per thread
+	DefaultHotThreadsSnapshots = 10
+	HotThreadsTopFrames        = 5
+	HotThreadsTypeCPU          = "cpu"
+	HotThreadsTypeWait         = "wait"
+	HotThreadsTypeBlock        = "block"
+
+	// escope index profile: parallel field requests and top values shown per keyword field
+	DefaultProfileConcurrency = 4
+	DefaultProfileTopValues   = 5
+
+	// escope index values: terms shown, target histogram buckets and bar chart width
+	DefaultValuesTop     = 10
+	DefaultValuesBuckets = 20
+	ValuesBarWidth       = 40
+
+	// escope search: hits returned when the request sets no size
+	DefaultSearchSize = 10
+
+	// A data node is near flood stage once its free space above that watermark drops below
+	// this share of the disk
+	NearFloodStagePercent = 5.0
+
 	// Replica thresholds
 	OptimalReplicaCount       = 2 // Optimal replica count
 	MaxAcceptableReplicaCount = 3 // Maximum acceptable without warning
@@ -179,6 +253,8 @@ const (
 	DefaultConfigTimeout2 = 30
 	ConfigFilePath        = ".escope.yaml"
 	ConfigFileEnvPath     = "$HOME/.escope.yaml"
+	ConfigFileMode        = 0600
+	PassphraseEnvVar      = "ESCOPE_PASSPHRASE"
 
 	GCYoung                     = "young"
 	GCOld                       = "old"
@@ -250,4 +326,17 @@ const (
 	RecommendationCategoryGeneral = "GENERAL"
 
 	CalculatorMsgSaved = "Saved"
+
+	// Disk-based shard allocation settings, read from the cluster settings with defaults
+	DiskThresholdEnabledSetting    = "cluster.routing.allocation.disk.threshold_enabled"
+	DiskWatermarkLowSetting        = "cluster.routing.allocation.disk.watermark.low"
+	DiskWatermarkHighSetting       = "cluster.routing.allocation.disk.watermark.high"
+	DiskWatermarkFloodStageSetting = "cluster.routing.allocation.disk.watermark.flood_stage"
+	DiskWatermarkMaxHeadroomSuffix = ".max_headroom"
+	ClusterSettingsTransientField  = "transient"
+	ClusterSettingsPersistentField = "persistent"
+	ClusterSettingsDefaultsField   = "defaults"
+	DefaultDiskWatermarkLow        = "85%"
+	DefaultDiskWatermarkHigh       = "90%"
+	DefaultDiskWatermarkFloodStage = "95%"
 )
@@ -38,7 +38,23 @@ const (
 	ErrHostIsRequired              = "host is required"
 	ErrUsernameRequired            = "username is required in secure mode"
 	ErrPasswordRequired            = "password is required in secure mode"
+	ErrConflictingTokenAuth        = "api_key and bearer_token cannot be used together"
 	ErrConnectionFailed2           = "connection failed: %w"
+	ErrTLSCheckFailed              = "TLS check failed: %w"
+	ErrSigV4RequiresOpenSearch     = "aws_region (SigV4 signing) requires type opensearch"
+	ErrSecretEnvNotSet             = "environment variable %s referenced by the config is not set"
+	ErrSecretFileRead              = "failed to read secret file %s: %w"
+	ErrSecretDecryptFailed         = "failed to decrypt secret: %w"
+	ErrSecretResolveFailed         = "failed to resolve credentials for host '%s': %w"
+	ErrPassphraseRequired          = "config secrets are encrypted: set %s or run in a terminal to enter the passphrase"
+	ErrPassphraseEmpty             = "passphrase must not be empty"
+	ErrPassphraseMismatch          = "passphrases do not match"
+	ErrWrongPassphrase             = "wrong passphrase"
+	ErrUnsupportedKDF              = "unsupported key derivation %q"
+	ErrConfigAlreadyEncrypted      = "config secrets are already encrypted"
+	ErrConfigNotEncrypted          = "config secrets are not encrypted"
+	ErrFailedToEncryptConfig       = "failed to encrypt config: %w"
+	ErrFailedToDecryptConfig       = "failed to decrypt config: %w"
 	ErrFailedToSetTimeout          = "failed to set connection timeout: %w"
 	ErrFailedToGetTimeout          = "failed to get connection timeout: %w"
 	ErrFailedToGetAppConfig        = "failed to get app config: %w"
@@ -56,10 +72,37 @@ const (
 	ErrClusterStatsRequestFailed2  = "cluster stats request failed: %w"
 	ErrClusterHealthRequestFailed2 = "cluster health request failed: %w"
 	ErrShardsRequestFailed2        = "shards request failed: %w"
+	ErrAllocationExplainFailed     = "allocation explain request failed: %w"
+	ErrNoUnassignedShards          = "no unassigned shards to explain; use --index and --shard to explain an assigned shard"
 	ErrIndexStatsRequestFailed     = "index stats request failed: %w"
 	ErrIndicesRequestFailed2       = "indices request failed: %w"
 	ErrFailedToGetSegmentsInfo     = "failed to get segments info: %w"
 	ErrFailedToGetNodeInfo         = "failed to get node info: %w"
+	ErrRulesFileRead               = "failed to read rules file: %w"
+	ErrRulesFileParse              = "failed to parse rules file: %w"
+	ErrSampleDecodeFailed          = "failed to decode sample %d: %w"
+	ErrClusterSettingsFailed       = "cluster settings request failed: %w"
+	ErrThreadPoolNotFound          = "no thread pools match the given node and pool filters"
+	ErrHotThreadsFailed            = "hot threads request failed: %w"
+	ErrInvalidHotThreadsType       = "invalid --thread-type '%s': use cpu, wait or block"
+	ErrHotThreadsSaveFailed        = "failed to save raw hot threads: %w"
+	ErrInvalidIndexSide            = "invalid index '%s': use <index> or <host-alias>:<index>"
+	ErrDesiredSettingsRead         = "failed to read desired settings file: %w"
+	ErrDesiredSettingsParse        = "failed to parse desired settings file: %w"
+	ErrDesiredSettingsEmpty        = "desired settings file %s has no settings"
+	ErrFieldProfileFailed          = "field profile request failed: %w"
+	ErrFieldValuesFailed           = "field values request failed: %w"
+	ErrFieldUsageFailed            = "field usage stats request failed: %w"
+	ErrFieldNotInMapping           = "field '%s' not found in the mapping of %s"
+	ErrFieldNotAggregatable        = "field '%s' is %s and cannot be aggregated (for text try a .keyword subfield)"
+	ErrInvalidHistogramInterval    = "invalid interval '%s' for numeric field: use a number such as 100"
+	ErrInvalidQueryJSON            = "invalid query JSON: %w"
+	ErrSearchFailed                = "search request failed: %w"
+	ErrSearchQueryRead             = "failed to read query file: %w"
+	ErrSearchQueryConflict         = "use either --query <file> or --q <query string>, not both"
+	ErrExplainFailed               = "explain request failed: %w"
+	ErrValidateQueryFailed         = "validate query request failed: %w"
+	ErrDocumentNotFound            = "document %s not found in %s"
 
 	MsgHostLabel             = "   Host: %s"
 	MsgUsernameLabel         = "   Username: %s"
@@ -67,12 +110,31 @@ const (
 	MsgPasswordHidden        = "***"
 	MsgPasswordNotSet        = "(not set)"
 	MsgSecureLabel           = "   Secure: %t"
+	MsgRulesFileLabel        = "   Rules File: %s"
+	MsgAPIKeyLabel           = "   API Key: %s"
+	MsgBearerTokenLabel      = "   Bearer Token: %s"
+	MsgAddressesLabel        = "   Failover Hosts: %s"
+	MsgSniffLabel            = "   Sniff: %t"
+	MsgCloudIDLabel          = "   Cloud ID: %s"
+	MsgTypeLabel             = "   Type: %s"
+	MsgAWSSigningLabel       = "   AWS SigV4: region %s, service %s, profile %s"
+	MsgServedByFailover      = "Note: served by %s, unreachable: %s"
+	MsgServedBy              = "Note: served by %s"
+	MsgPassphrasePrompt      = "Config passphrase: "
+	MsgPassphraseConfirm     = "Repeat passphrase: "
+	MsgCACertLabel           = "   CA Certificate: %s"
+	MsgClientCertLabel       = "   Client Certificate: %s"
+	MsgClientKeyLabel        = "   Client Key: %s"
+	MsgCAFingerprintLabel    = "   CA Fingerprint: %s"
+	MsgInsecureSkipLabel     = "   Insecure Skip Verify: %t"
 	MsgTimeoutGeneric        = "Operation timed out. The request took longer than expected to complete."
 	MsgUnassignedShards      = "Unassigned shards: %d"
 	MsgRelocatingShards      = "Relocating shards: %d"
 	MsgInitializingShards    = "Initializing shards: %d"
 	MsgShardUnbalanced       = "Shard distribution uneven (ratio: %.2f)"
 	MsgInvestigateUnassigned = "Investigate and resolve %d unassigned shards"
+	MsgUnassignedShardReason = "%s[%d] %s: %s"
+	MsgExplainUnassigned     = "Run 'escope shard explain --index %s --shard %d%s' for per-node details"
 	MsgConsiderRebalancing   = "Consider rebalancing shards across nodes for better distribution."
 	MsgShardHealthy          = "Shard distribution is healthy"
 	MsgNodeBalanceGood       = "Node balance is good"
@@ -81,6 +143,13 @@ const (
 	MsgHighMemoryUsage       = "High memory usage"
 	MsgHighHeapUsage         = "High heap usage"
 	MsgHighDiskUsage         = "High disk usage"
+	MsgDiskFloodStage        = "%s is past the flood stage watermark: indices with shards on it are read-only"
+	MsgDiskNearFloodStage    = "%s has %s left before the flood stage watermark: indices with shards on it become read-only there"
+	MsgDiskWatermarksDefault = " (Elasticsearch defaults, cluster settings unavailable)"
+	MsgDiskWatermarksOff     = " (disk threshold decider disabled)"
+	MsgThreadPoolRejections  = "Thread Pool Rejections: %s on %s (+%d in %.0fs, %.1f/s)"
+	MsgHotThreadsHint        = "See what it is busy with: escope node hotthreads --name %s"
+	MsgParentBreakerPressure = "Parent Breaker Pressure: %s at %.1f%% of %s (requests are rejected with circuit_breaking_exception at 100%%)"
 )
 const (
 	CalculatorErrSnapshotMissing = "no saved calculator snapshot for this host; save with ctrl+s first, or run without --snapshot for built-in defaults, or use --from-cluster for live cluster data"
@@ -1,22 +1,114 @@
 package elastic
 
 import (
-	"github.com/elastic/go-elasticsearch/v8"
+	"encoding/base64"
 	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
 )
 
-func NewClient(host, username, password string, secure bool) *elasticsearch.Client {
-	if host == "" {
+// ClientOptions holds the connection settings of one host.
+// Authentication precedence: APIKey, then BearerToken, then Username/Password.
+// CACert, ClientCert and ClientKey are PEM file paths.
+// Addresses are further nodes of the same cluster, tried when Host fails.
+// CloudID is only decoded when Host is empty.
+// Type opensearch with an AWSRegion signs requests with AWS SigV4 instead of the credentials above.
+type ClientOptions struct {
+	Host               string
+	Addresses          []string
+	Sniff              bool
+	CloudID            string
+	Type               string
+	AWSRegion          string
+	AWSProfile         string
+	AWSService         string
+	Username           string
+	Password           string
+	APIKey             string
+	BearerToken        string
+	CACert             string
+	ClientCert         string
+	ClientKey          string
+	CAFingerprint      string
+	InsecureSkipVerify bool
+
+	// Tracker, when set, records which node served each request
+	Tracker *NodeTracker
+}
+
+// AllAddresses returns Host followed by the additional addresses, without duplicates
+func (o ClientOptions) AllAddresses() []string {
+	seen := make(map[string]bool)
+	var out []string
+	primary := o.Host
+	if primary == "" && o.CloudID != "" {
+		primary, _ = HostFromCloudID(o.CloudID)
+	}
+	for _, addr := range append([]string{primary}, o.Addresses...) {
+		addr = strings.TrimSpace(addr)
+		if addr == "" || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		out = append(out, addr)
+	}
+	return out
+}
+
+func NewClient(opts ClientOptions) *elasticsearch.Client {
+	addresses := opts.AllAddresses()
+	if len(addresses) == 0 {
 		log.Fatalf("Failed to create Elasticsearch client: host is required")
 	}
 
 	cfg := elasticsearch.Config{
-		Addresses: []string{host},
+		Addresses:            addresses,
+		DiscoverNodesOnStart: opts.Sniff,
+	}
+	// A failed connection is retried on the next node, so allow one attempt per node
+	if len(addresses) > defaultMaxRetries {
+		cfg.MaxRetries = len(addresses)
 	}
 
-	if username != "" && password != "" {
-		cfg.Username = username
-		cfg.Password = password
+	switch {
+	case opts.APIKey != "":
+		cfg.APIKey = EncodeAPIKey(opts.APIKey)
+	case opts.BearerToken != "":
+		cfg.ServiceToken = opts.BearerToken
+	case opts.Username != "" && opts.Password != "":
+		cfg.Username = opts.Username
+		cfg.Password = opts.Password
+	}
+
+	tlsCfg, err := BuildTLSConfig(opts)
+	if err != nil {
+		log.Fatalf("Failed to create Elasticsearch client: %v", err)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsCfg != nil {
+		transport.TLSClientConfig = tlsCfg
+	}
+	if len(addresses) > 1 {
+		// Give up on an unreachable node quickly so the next one is tried within the command timeout
+		transport.DialContext = (&net.Dialer{Timeout: failoverDialTimeout, KeepAlive: 30 * time.Second}).DialContext
+	}
+	cfg.Transport = transport
+	if opts.UsesSigV4() {
+		creds, err := LoadAWSCredentials(opts.AWSProfile)
+		if err != nil {
+			log.Fatalf("Failed to create Elasticsearch client: %v", err)
+		}
+		cfg.Transport = &sigV4Transport{next: cfg.Transport, creds: creds, region: opts.AWSRegion, service: opts.awsService(), now: time.Now}
+	}
+	if opts.Type == TypeOpenSearch {
+		cfg.Transport = &productHeaderTransport{next: cfg.Transport}
+	}
+	if opts.Tracker != nil {
+		cfg.Transport = &trackingTransport{next: cfg.Transport, tracker: opts.Tracker}
 	}
 
 	client, err := elasticsearch.NewClient(cfg)
@@ -25,3 +117,26 @@ func NewClient(host, username, password string, secure bool) *elasticsearch.Clie
 	}
 	return client
 }
+
+// UsesSigV4 reports whether requests are signed for Amazon OpenSearch Service
+func (o ClientOptions) UsesSigV4() bool {
+	return o.Type == TypeOpenSearch && o.AWSRegion != ""
+}
+
+// awsService is "es" for OpenSearch Service domains or "aoss" for OpenSearch Serverless
+func (o ClientOptions) awsService() string {
+	if o.AWSService != "" {
+		return o.AWSService
+	}
+	return defaultAWSService
+}
+
+// EncodeAPIKey accepts either the "id:api_key" pair or the already base64-encoded
+// value returned by the create API key API, and returns the encoded form.
+func EncodeAPIKey(key string) string {
+	key = strings.TrimSpace(key)
+	if strings.Contains(key, ":") {
+		return base64.StdEncoding.EncodeToString([]byte(key))
+	}
+	return key
+}
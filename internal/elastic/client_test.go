@@ -0,0 +1,82 @@
+package elastic
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEncodeAPIKey(t *testing.T) {
+	if got := EncodeAPIKey("VuaCfGcBCdbkQm-e5aOx:ui2lp2axTNmsyakw9tvNnw"); got != "VnVhQ2ZHY0JDZGJrUW0tZTVhT3g6dWkybHAyYXhUTm1zeWFrdzl0dk5udw==" {
+		t.Fatalf("id:key pair: %q", got)
+	}
+	if got := EncodeAPIKey(" VnVhQ2ZHY0JDZGJrUW0tZTVhT3g6dWkybHAyYXhUTm1zeWFrdzl0dk5udw== "); got != "VnVhQ2ZHY0JDZGJrUW0tZTVhT3g6dWkybHAyYXhUTm1zeWFrdzl0dk5udw==" {
+		t.Fatalf("encoded key: %q", got)
+	}
+}
+
+func TestAllAddresses(t *testing.T) {
+	opts := ClientOptions{Host: "http://es-1:9200", Addresses: []string{" http://es-2:9200", "http://es-1:9200", ""}}
+	got := opts.AllAddresses()
+	if len(got) != 2 || got[0] != "http://es-1:9200" || got[1] != "http://es-2:9200" {
+		t.Fatalf("addresses: %v", got)
+	}
+}
+
+func TestFailoverToNextNode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	tracker := NewNodeTracker()
+	client := NewClient(ClientOptions{Host: "http://127.0.0.1:1", Addresses: []string{server.URL}, Tracker: tracker})
+	res, err := client.Info()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if tracker.ServedBy() != server.URL {
+		t.Fatalf("served by %q, want %q", tracker.ServedBy(), server.URL)
+	}
+	if failed := tracker.FailedNodes(); len(failed) != 1 || failed[0] != "http://127.0.0.1:1" {
+		t.Fatalf("failed nodes: %v", failed)
+	}
+}
+
+func TestHostFromCloudID(t *testing.T) {
+	cases := map[string]string{
+		"prod:" + base64.StdEncoding.EncodeToString([]byte("us-east-1.aws.found.io$abc123$kib456")):      "https://abc123.us-east-1.aws.found.io",
+		"prod:" + base64.StdEncoding.EncodeToString([]byte("us-east-1.aws.found.io:9243$abc123$kib456")): "https://abc123.us-east-1.aws.found.io:9243",
+	}
+	for id, want := range cases {
+		if got, err := HostFromCloudID(id); err != nil || got != want {
+			t.Errorf("%s: got %q, %v", id, got, err)
+		}
+	}
+	if _, err := HostFromCloudID("no-data"); err == nil {
+		t.Error("expected error for malformed cloud id")
+	}
+}
+
+func TestNormalizeOpenSearchNodes(t *testing.T) {
+	result := map[string]interface{}{
+		"nodes": map[string]interface{}{
+			"n1": map[string]interface{}{
+				"roles":             []interface{}{"cluster_manager", "data"},
+				"transport_address": "10.0.0.1:9300",
+			},
+		},
+	}
+	normalizeNodes(result)
+	node := result["nodes"].(map[string]interface{})["n1"].(map[string]interface{})
+	if roles := node["roles"].([]interface{}); len(roles) != 3 || roles[2] != "master" {
+		t.Fatalf("roles: %v", roles)
+	}
+	if node["ip"] != "10.0.0.1" {
+		t.Fatalf("ip: %v", node["ip"])
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"strings"
 
 	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
 	"github.com/mertbahardogan/escope/internal/constants"
 	"github.com/mertbahardogan/escope/internal/interfaces"
 	"github.com/mertbahardogan/escope/internal/util"
@@ -48,6 +50,7 @@ func (cw *ClientWrapper) GetClusterHealth(ctx context.Context) (map[string]inter
 	if err := decodeJSONResponse(res.Body, &result); err != nil {
 		return nil, err
 	}
+	normalizeClusterHealth(result)
 	return result, nil
 }
 
@@ -60,6 +63,49 @@ func (cw *ClientWrapper) GetClusterStats(ctx context.Context) (map[string]interf
 	if err := decodeJSONResponse(res.Body, &result); err != nil {
 		return nil, err
 	}
+	normalizeClusterStats(result)
+	return result, nil
+}
+
+// GetClusterSettings returns the transient, persistent and default cluster settings with
+// flat keys, so the effective value of a setting can be looked up in that order
+func (cw *ClientWrapper) GetClusterSettings(ctx context.Context) (map[string]interface{}, error) {
+	res, err := cw.client.Cluster.GetSettings(
+		cw.client.Cluster.GetSettings.WithContext(ctx),
+		cw.client.Cluster.GetSettings.WithIncludeDefaults(true),
+		cw.client.Cluster.GetSettings.WithFlatSettings(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := decodeJSONResponse(res.Body, &result); err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		if err := checkElasticsearchError(result); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("cluster settings request failed: %s", res.Status())
+	}
+	return result, nil
+}
+
+// GetServerInfo returns the root endpoint document; version.distribution is
+// "elasticsearch" or "opensearch"
+func (cw *ClientWrapper) GetServerInfo(ctx context.Context) (map[string]interface{}, error) {
+	res, err := cw.client.Info(cw.client.Info.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := decodeJSONResponse(res.Body, &result); err != nil {
+		return nil, err
+	}
+	if err := checkElasticsearchError(result); err != nil {
+		return nil, err
+	}
+	normalizeServerInfo(result)
 	return result, nil
 }
 
@@ -76,6 +122,7 @@ func (cw *ClientWrapper) GetNodesInfo(ctx context.Context) (map[string]interface
 	if err := decodeJSONResponse(res.Body, &result); err != nil {
 		return nil, err
 	}
+	normalizeNodes(result)
 	return result, nil
 }
 
@@ -88,9 +135,42 @@ func (cw *ClientWrapper) GetNodesStats(ctx context.Context) (map[string]interfac
 	if err := decodeJSONResponse(res.Body, &result); err != nil {
 		return nil, err
 	}
+	normalizeNodes(result)
 	return result, nil
 }
 
+// GetHotThreads returns the plain text hot threads report; nodes may hold node ids, names
+// or wildcards, and an empty list samples every node
+func (cw *ClientWrapper) GetHotThreads(ctx context.Context, nodes []string, threadType string, snapshots int) (string, error) {
+	opts := []func(*esapi.NodesHotThreadsRequest){
+		cw.client.Nodes.HotThreads.WithContext(ctx),
+		cw.client.Nodes.HotThreads.WithDocumentType(threadType),
+		cw.client.Nodes.HotThreads.WithSnapshots(snapshots),
+	}
+	if len(nodes) > 0 {
+		opts = append(opts, cw.client.Nodes.HotThreads.WithNodeID(nodes...))
+	}
+	res, err := cw.client.Nodes.HotThreads(opts...)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	if res.IsError() {
+		var result map[string]interface{}
+		if json.Unmarshal(body, &result) == nil {
+			if err := checkElasticsearchError(result); err != nil {
+				return "", err
+			}
+		}
+		return "", fmt.Errorf("hot threads request failed: %s", res.Status())
+	}
+	return string(body), nil
+}
+
 func (cw *ClientWrapper) fetchIndexAliases(ctx context.Context) (map[string]string, error) {
 	res, err := cw.client.Cat.Aliases(
 		cw.client.Cat.Aliases.WithContext(ctx),
@@ -209,6 +289,33 @@ func (cw *ClientWrapper) GetShards(ctx context.Context) (map[string]interface{},
 	return map[string]interface{}{"": processShards(shards)}, nil
 }
 
+// GetAllocationExplain calls the cluster allocation explain API; a nil body explains the
+// first unassigned shard the cluster finds
+func (cw *ClientWrapper) GetAllocationExplain(ctx context.Context, body []byte) (map[string]interface{}, error) {
+	opts := []func(*esapi.ClusterAllocationExplainRequest){
+		cw.client.Cluster.AllocationExplain.WithContext(ctx),
+		cw.client.Cluster.AllocationExplain.WithIncludeDiskInfo(true),
+	}
+	if body != nil {
+		opts = append(opts, cw.client.Cluster.AllocationExplain.WithBody(bytes.NewReader(body)))
+	}
+	res, err := cw.client.Cluster.AllocationExplain(opts...)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := decodeJSONResponse(res.Body, &result); err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		if err := checkElasticsearchError(result); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("allocation explain request failed: %s", res.Status())
+	}
+	return result, nil
+}
+
 func (cw *ClientWrapper) GetLuceneStats(ctx context.Context) (map[string]interface{}, error) {
 	res, err := cw.client.Indices.Stats(cw.client.Indices.Stats.WithContext(ctx))
 	if err != nil {
@@ -387,6 +494,26 @@ func (cw *ClientWrapper) GetIndexMapping(ctx context.Context, indexName string)
 	return result, nil
 }
 
+func (cw *ClientWrapper) GetFieldUsageStats(ctx context.Context, indexName string) (map[string]interface{}, error) {
+	res, err := cw.client.Indices.FieldUsageStats(indexName,
+		cw.client.Indices.FieldUsageStats.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := decodeJSONResponse(res.Body, &result); err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		if err := checkElasticsearchError(result); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("field usage stats request failed: %s", res.Status())
+	}
+	return result, nil
+}
+
 func (cw *ClientWrapper) GetIndexSettings(ctx context.Context, indexName string) (map[string]interface{}, error) {
 	res, err := cw.client.Indices.GetSettings(
 		cw.client.Indices.GetSettings.WithContext(ctx),
@@ -405,6 +532,27 @@ func (cw *ClientWrapper) GetIndexSettings(ctx context.Context, indexName string)
 	return result, nil
 }
 
+// GetIndexSettingsWithDefaults also returns the settings left at their defaults, under
+// "defaults" next to "settings"
+func (cw *ClientWrapper) GetIndexSettingsWithDefaults(ctx context.Context, indexName string) (map[string]interface{}, error) {
+	res, err := cw.client.Indices.GetSettings(
+		cw.client.Indices.GetSettings.WithContext(ctx),
+		cw.client.Indices.GetSettings.WithIndex(indexName),
+		cw.client.Indices.GetSettings.WithIncludeDefaults(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := decodeJSONResponse(res.Body, &result); err != nil {
+		return nil, err
+	}
+	if err := checkElasticsearchError(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (cw *ClientWrapper) CountWithBody(ctx context.Context, indexName string, body []byte) (int64, error) {
 	res, err := cw.client.Count(
 		cw.client.Count.WithContext(ctx),
@@ -452,6 +600,56 @@ func (cw *ClientWrapper) SearchWithBody(ctx context.Context, indexName string, b
 	return result, nil
 }
 
+// Explain returns the response for a missing document as well, with "found": false
+func (cw *ClientWrapper) Explain(ctx context.Context, indexName, documentID string, body []byte) (map[string]interface{}, error) {
+	res, err := cw.client.Explain(indexName, documentID,
+		cw.client.Explain.WithContext(ctx),
+		cw.client.Explain.WithBody(bytes.NewReader(body)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := decodeJSONResponse(res.Body, &result); err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		if err := checkElasticsearchError(result); err != nil {
+			return nil, err
+		}
+		if res.StatusCode == http.StatusNotFound {
+			result["found"] = false
+			return result, nil
+		}
+		return nil, fmt.Errorf("explain request failed: %s", res.Status())
+	}
+	return result, nil
+}
+
+func (cw *ClientWrapper) ValidateQuery(ctx context.Context, indexName string, body []byte) (map[string]interface{}, error) {
+	res, err := cw.client.Indices.ValidateQuery(
+		cw.client.Indices.ValidateQuery.WithContext(ctx),
+		cw.client.Indices.ValidateQuery.WithIndex(indexName),
+		cw.client.Indices.ValidateQuery.WithBody(bytes.NewReader(body)),
+		cw.client.Indices.ValidateQuery.WithExplain(true),
+		cw.client.Indices.ValidateQuery.WithRewrite(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := decodeJSONResponse(res.Body, &result); err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		if err := checkElasticsearchError(result); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("validate query request failed: %s", res.Status())
+	}
+	return result, nil
+}
+
 func (cw *ClientWrapper) makeIndicesRequest(ctx context.Context, sortParam string) ([]map[string]interface{}, error) {
 	res, err := cw.client.Cat.Indices(
 		cw.client.Cat.Indices.WithContext(ctx),
@@ -0,0 +1,70 @@
+package elastic
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultMaxRetries mirrors the go-elasticsearch transport default
+	defaultMaxRetries   = 3
+	failoverDialTimeout = 5 * time.Second
+)
+
+// NodeTracker records the node that answered the most recent request
+// and the nodes that could not be reached
+type NodeTracker struct {
+	mu     sync.Mutex
+	served string
+	failed []string
+}
+
+func NewNodeTracker() *NodeTracker {
+	return &NodeTracker{}
+}
+
+// ServedBy returns the scheme://host:port of the last node that answered, or "" before any response
+func (t *NodeTracker) ServedBy() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.served
+}
+
+// FailedNodes returns the nodes a request failed on before another node answered
+func (t *NodeTracker) FailedNodes() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.failed...)
+}
+
+func (t *NodeTracker) record(node string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err != nil {
+		for _, n := range t.failed {
+			if n == node {
+				return
+			}
+		}
+		t.failed = append(t.failed, node)
+		return
+	}
+	t.served = node
+}
+
+// trackingTransport reports every round trip to a NodeTracker
+type trackingTransport struct {
+	next    http.RoundTripper
+	tracker *NodeTracker
+}
+
+func (t *trackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.next.RoundTrip(req)
+	if err != nil && req.Context().Err() != nil {
+		// The command timed out or was cancelled, the node is not to blame
+		return res, err
+	}
+	t.tracker.record(req.URL.Scheme+"://"+req.URL.Host, err)
+	return res, err
+}
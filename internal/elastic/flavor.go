@@ -0,0 +1,64 @@
+package elastic
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Connection types of an alias
+const (
+	TypeElasticsearch = "elasticsearch"
+	TypeOpenSearch    = "opensearch"
+)
+
+// ValidateType accepts an empty type as Elasticsearch
+func ValidateType(connType string) error {
+	switch connType {
+	case "", TypeElasticsearch, TypeOpenSearch:
+		return nil
+	}
+	return fmt.Errorf("unsupported connection type %q (supported: %s, %s)", connType, TypeElasticsearch, TypeOpenSearch)
+}
+
+// HostFromCloudID decodes an Elastic Cloud ID ("name:base64(host$es_uuid$kibana_uuid)")
+// into the https URL of the Elasticsearch endpoint
+func HostFromCloudID(cloudID string) (string, error) {
+	_, encoded, ok := strings.Cut(strings.TrimSpace(cloudID), ":")
+	if !ok || encoded == "" {
+		return "", fmt.Errorf("invalid cloud_id: expected <name>:<base64 data>")
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("invalid cloud_id: %w", err)
+	}
+	parts := strings.Split(string(data), "$")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("invalid cloud_id: missing host or Elasticsearch id")
+	}
+
+	domain, port := parts[0], "443"
+	if host, p, found := strings.Cut(domain, ":"); found {
+		domain, port = host, p
+	}
+	url := "https://" + parts[1] + "." + domain
+	if port != "443" {
+		url += ":" + port
+	}
+	return url, nil
+}
+
+// productHeaderTransport marks OpenSearch responses as Elasticsearch ones. go-elasticsearch
+// refuses to talk to a server that does not send X-Elastic-Product.
+type productHeaderTransport struct {
+	next http.RoundTripper
+}
+
+func (t *productHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.next.RoundTrip(req)
+	if err == nil && res.Header.Get("X-Elastic-Product") == "" {
+		res.Header.Set("X-Elastic-Product", "Elasticsearch")
+	}
+	return res, err
+}
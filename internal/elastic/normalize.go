@@ -0,0 +1,102 @@
+package elastic
+
+import (
+	"net"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+)
+
+// OpenSearch renamed the master role and a few fields to cluster_manager. The helpers below
+// copy such values to their Elasticsearch names, so services only deal with one response shape.
+// They only fill keys that are missing and are no-ops on Elasticsearch responses.
+
+const roleClusterManager = "cluster_manager"
+
+// normalizeNodes fixes the "nodes" map of the nodes info and nodes stats APIs
+func normalizeNodes(result map[string]interface{}) {
+	nodes, ok := result[constants.NodesField].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, n := range nodes {
+		node, ok := n.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if roles, ok := node[constants.RolesField].([]interface{}); ok {
+			node[constants.RolesField] = normalizeRoles(roles)
+		}
+		if _, ok := node[constants.IPField].(string); !ok {
+			if ip := hostOf(node["transport_address"]); ip != "" {
+				node[constants.IPField] = ip
+			}
+		}
+	}
+}
+
+func normalizeRoles(roles []interface{}) []interface{} {
+	hasMaster, hasManager := false, false
+	for _, r := range roles {
+		switch r {
+		case constants.NodeRoleMaster:
+			hasMaster = true
+		case roleClusterManager:
+			hasManager = true
+		}
+	}
+	if hasManager && !hasMaster {
+		roles = append(roles, constants.NodeRoleMaster)
+	}
+	return roles
+}
+
+// normalizeClusterStats copies nodes.count.cluster_manager to nodes.count.master
+func normalizeClusterStats(result map[string]interface{}) {
+	nodes, ok := result[constants.NodesField].(map[string]interface{})
+	if !ok {
+		return
+	}
+	copyMissing(nodes[constants.CountField], roleClusterManager, constants.NodeRoleMaster)
+}
+
+// normalizeClusterHealth copies discovered_cluster_manager to discovered_master
+func normalizeClusterHealth(result map[string]interface{}) {
+	copyMissing(result, "discovered_cluster_manager", "discovered_master")
+}
+
+// normalizeServerInfo makes sure version.distribution is set; Elasticsearch omits it
+func normalizeServerInfo(result map[string]interface{}) {
+	version, ok := result["version"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	if d, ok := version["distribution"].(string); !ok || d == "" {
+		version["distribution"] = TypeElasticsearch
+	}
+}
+
+func copyMissing(v interface{}, from, to string) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if _, exists := m[to]; exists {
+		return
+	}
+	if value, exists := m[from]; exists {
+		m[to] = value
+	}
+}
+
+// hostOf returns the host part of an "ip:port" transport address
+func hostOf(v interface{}) string {
+	addr, ok := v.(string)
+	if !ok || addr == "" {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return strings.Trim(host, "[]")
+	}
+	return addr
+}
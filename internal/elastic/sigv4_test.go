@@ -0,0 +1,46 @@
+package elastic
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// get-vanilla from the AWS SigV4 test suite
+func TestSignRequestVanilla(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	creds := AWSCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+	signRequest(req, sha256Hex(nil), creds, "us-east-1", "service", now)
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Fatalf("authorization:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestParseCredentialsFile(t *testing.T) {
+	file := `
+[default]
+aws_access_key_id = AKIADEFAULT
+aws_secret_access_key = secret-default
+
+[ops]
+aws_access_key_id=AKIAOPS
+aws_secret_access_key=secret-ops
+aws_session_token=token
+`
+	creds, found, err := parseCredentialsFile(strings.NewReader(file), "ops")
+	if err != nil || !found {
+		t.Fatalf("found=%v err=%v", found, err)
+	}
+	if creds.AccessKeyID != "AKIAOPS" || creds.SecretAccessKey != "secret-ops" || creds.SessionToken != "token" {
+		t.Fatalf("creds: %+v", creds)
+	}
+	if _, found, _ := parseCredentialsFile(strings.NewReader(file), "missing"); found {
+		t.Fatal("expected missing profile")
+	}
+}
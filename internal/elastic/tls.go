@@ -0,0 +1,134 @@
+package elastic
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// HasTLSSettings reports whether any custom TLS option is set
+func (o ClientOptions) HasTLSSettings() bool {
+	return o.CACert != "" || o.ClientCert != "" || o.ClientKey != "" || o.CAFingerprint != "" || o.InsecureSkipVerify
+}
+
+// BuildTLSConfig turns the TLS options into a tls.Config. It returns nil when no option is set
+// so the default transport is used unchanged.
+func BuildTLSConfig(opts ClientOptions) (*tls.Config, error) {
+	if !opts.HasTLSSettings() {
+		return nil, nil
+	}
+
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if opts.CACert != "" {
+		pem, err := os.ReadFile(opts.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in %s", opts.CACert)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if (opts.ClientCert == "") != (opts.ClientKey == "") {
+		return nil, fmt.Errorf("client_cert and client_key must be set together")
+	}
+	if opts.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(opts.ClientCert, opts.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if opts.CAFingerprint != "" {
+		fingerprint, err := decodeFingerprint(opts.CAFingerprint)
+		if err != nil {
+			return nil, err
+		}
+		// The pinned certificate replaces chain verification, same as go-elasticsearch's
+		// CertificateFingerprint, but keeps client certificates working.
+		tlsCfg.InsecureSkipVerify = true
+		tlsCfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			for _, raw := range rawCerts {
+				digest := sha256.Sum256(raw)
+				if bytes.Equal(digest[:], fingerprint) {
+					return nil
+				}
+			}
+			return fmt.Errorf("no certificate presented by the server matches ca_fingerprint %s", opts.CAFingerprint)
+		}
+	}
+
+	if opts.InsecureSkipVerify {
+		tlsCfg.InsecureSkipVerify = true
+	}
+
+	return tlsCfg, nil
+}
+
+// CheckTLS performs a TLS handshake using the configured options. With several addresses
+// it succeeds as soon as one node completes the handshake, since the others may be down.
+// Hosts without https are rejected when TLS options are set, and skipped otherwise.
+func CheckTLS(opts ClientOptions, timeout time.Duration) error {
+	var lastErr error
+	for _, addr := range opts.AllAddresses() {
+		u, err := url.Parse(addr)
+		if err != nil {
+			return fmt.Errorf("invalid host %q: %w", addr, err)
+		}
+		if u.Scheme != "https" {
+			if opts.HasTLSSettings() {
+				return fmt.Errorf("TLS settings require an https host, got %q", addr)
+			}
+			continue
+		}
+		if lastErr = handshake(opts, u, timeout); lastErr == nil {
+			return nil
+		}
+	}
+	return lastErr
+}
+
+func handshake(opts ClientOptions, u *url.URL, timeout time.Duration) error {
+	tlsCfg, err := BuildTLSConfig(opts)
+	if err != nil {
+		return err
+	}
+	if tlsCfg == nil {
+		tlsCfg = &tls.Config{}
+	}
+	tlsCfg.ServerName = u.Hostname()
+
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", addr, tlsCfg)
+	if err != nil {
+		return fmt.Errorf("TLS handshake with %s failed: %w", addr, err)
+	}
+	return conn.Close()
+}
+
+// decodeFingerprint accepts the hex SHA-256 fingerprint with or without colons,
+// as printed by openssl or by Elasticsearch on first start
+func decodeFingerprint(value string) ([]byte, error) {
+	cleaned := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), ":", ""))
+	fingerprint, err := hex.DecodeString(cleaned)
+	if err != nil || len(fingerprint) != sha256.Size {
+		return nil, fmt.Errorf("ca_fingerprint must be a hex encoded SHA-256 digest")
+	}
+	return fingerprint, nil
+}
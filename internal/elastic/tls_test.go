@@ -0,0 +1,67 @@
+package elastic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	cert := server.Certificate()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256(cert.Raw)
+	fingerprint := strings.ToUpper(hex.EncodeToString(digest[:]))
+
+	cases := []struct {
+		name string
+		opts ClientOptions
+		ok   bool
+	}{
+		{"untrusted", ClientOptions{}, false},
+		{"ca cert", ClientOptions{CACert: caFile}, true},
+		{"fingerprint", ClientOptions{CAFingerprint: fingerprint}, true},
+		{"wrong fingerprint", ClientOptions{CAFingerprint: strings.Repeat("ab", sha256.Size)}, false},
+		{"insecure", ClientOptions{InsecureSkipVerify: true}, true},
+		{"key without cert", ClientOptions{ClientKey: caFile}, false},
+	}
+	for _, c := range cases {
+		c.opts.Host = server.URL
+		err := CheckTLS(c.opts, 2*time.Second)
+		if (err == nil) != c.ok {
+			t.Errorf("%s: err = %v", c.name, err)
+		}
+	}
+
+	if err := CheckTLS(ClientOptions{Host: "http://localhost:9200", InsecureSkipVerify: true}, time.Second); err == nil {
+		t.Error("expected TLS settings on an http host to be rejected")
+	}
+
+	// An http node first in a multi-host alias must not skip the https nodes after it
+	mixed := ClientOptions{Host: "http://localhost:9200", Addresses: []string{server.URL}}
+	if err := CheckTLS(mixed, 2*time.Second); err == nil {
+		t.Error("expected the untrusted https host after an http host to be handshaked")
+	}
+}
+
+func TestDecodeFingerprint(t *testing.T) {
+	colons := strings.TrimSuffix(strings.Repeat("AB:", sha256.Size), ":")
+	if _, err := decodeFingerprint(colons); err != nil {
+		t.Fatalf("colon separated: %v", err)
+	}
+	if _, err := decodeFingerprint("abcd"); err == nil {
+		t.Fatal("expected short fingerprint to be rejected")
+	}
+}
@@ -70,3 +70,20 @@ func DescribeCurrent() (string, error) {
 	}
 	return fmt.Sprintf("No index selected for %s (use: escope index use <index-or-alias>)", host), nil
 }
+
+// ResolveIndexName returns the --name flag value, or the index selected with 'escope index use'
+// for the current host when the flag is empty
+func ResolveIndexName(flagValue string) string {
+	if s := strings.TrimSpace(flagValue); s != "" {
+		return s
+	}
+	if idx, ok := ReadSelectedIndex(); ok {
+		return idx
+	}
+	return ""
+}
+
+func PrintIndexNameRequired() {
+	fmt.Println("Error: no index specified.")
+	fmt.Println("Use --name <index-or-alias>, or select once with: escope index use <index-or-alias>")
+}
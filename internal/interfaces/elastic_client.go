@@ -9,10 +9,13 @@ import (
 type ElasticClient interface {
 	GetClusterHealth(ctx context.Context) (map[string]interface{}, error)
 	GetClusterStats(ctx context.Context) (map[string]interface{}, error)
+	GetServerInfo(ctx context.Context) (map[string]interface{}, error)
+	GetClusterSettings(ctx context.Context) (map[string]interface{}, error)
 
 	GetNodes(ctx context.Context) (map[string]interface{}, error)
 	GetNodesInfo(ctx context.Context) (map[string]interface{}, error)
 	GetNodesStats(ctx context.Context) (map[string]interface{}, error)
+	GetHotThreads(ctx context.Context, nodes []string, threadType string, snapshots int) (string, error)
 
 	GetIndices(ctx context.Context) (map[string]interface{}, error)
 	GetIndicesWithSort(ctx context.Context, sortBy, sortOrder string) ([]map[string]interface{}, error)
@@ -20,6 +23,7 @@ type ElasticClient interface {
 
 	GetShards(ctx context.Context) (map[string]interface{}, error)
 	GetShardsWithSort(ctx context.Context, sortBy, sortOrder string) ([]map[string]interface{}, error)
+	GetAllocationExplain(ctx context.Context, body []byte) (map[string]interface{}, error)
 
 	GetLuceneStats(ctx context.Context) (map[string]interface{}, error)
 	GetSegments(ctx context.Context) (map[string]interface{}, error)
@@ -30,9 +34,13 @@ type ElasticClient interface {
 
 	GetIndexMapping(ctx context.Context, indexName string) (map[string]interface{}, error)
 	GetIndexSettings(ctx context.Context, indexName string) (map[string]interface{}, error)
+	GetIndexSettingsWithDefaults(ctx context.Context, indexName string) (map[string]interface{}, error)
+	GetFieldUsageStats(ctx context.Context, indexName string) (map[string]interface{}, error)
 
 	CountWithBody(ctx context.Context, indexName string, body []byte) (int64, error)
 	SearchWithBody(ctx context.Context, indexName string, body []byte) (map[string]interface{}, error)
+	Explain(ctx context.Context, indexName, documentID string, body []byte) (map[string]interface{}, error)
+	ValidateQuery(ctx context.Context, indexName string, body []byte) (map[string]interface{}, error)
 
 	Ping(ctx context.Context) error
 	GetClient() *elasticsearch.Client
@@ -3,86 +3,129 @@ package models
 import "time"
 
 type CheckNodeHealth struct {
-	Timestamp time.Time
-	NodeID    string
-	Name      string
-	CPUUsage  float64
-	HeapUsage float64
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	NodeID    string    `json:"node_id" yaml:"node_id"`
+	Name      string    `json:"name" yaml:"name"`
+	CPUUsage  float64   `json:"cpu_usage" yaml:"cpu_usage"`
+	HeapUsage float64   `json:"heap_usage" yaml:"heap_usage"`
 }
 
 type ShardHealth struct {
-	Timestamp          time.Time
-	StartedShards      int
-	InitializingShards int
-	RelocatingShards   int
-	UnassignedShards   int
+	Timestamp          time.Time `json:"timestamp" yaml:"timestamp"`
+	StartedShards      int       `json:"started_shards" yaml:"started_shards"`
+	InitializingShards int       `json:"initializing_shards" yaml:"initializing_shards"`
+	RelocatingShards   int       `json:"relocating_shards" yaml:"relocating_shards"`
+	UnassignedShards   int       `json:"unassigned_shards" yaml:"unassigned_shards"`
 }
 
 type IndexHealth struct {
-	Timestamp time.Time
-	Name      string
-	Health    string
-	Status    string
-	Docs      string
-	Size      string
-	Alias     string
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	Name      string    `json:"name" yaml:"name"`
+	Health    string    `json:"health" yaml:"health"`
+	Status    string    `json:"status" yaml:"status"`
+	Docs      string    `json:"docs" yaml:"docs"`
+	Size      string    `json:"size" yaml:"size"`
+	Alias     string    `json:"alias" yaml:"alias"`
 }
 
 type ResourceUsage struct {
-	Timestamp        time.Time
-	NodeCount        int
-	CPUUsage         float64
-	CPUUsageMin      float64
-	CPUUsageMax      float64
-	CPUUsageMinNode  string
-	CPUUsageMaxNode  string
-	HeapUsage        float64
-	HeapUsageMin     float64
-	HeapUsageMax     float64
-	HeapUsageMinNode string
-	HeapUsageMaxNode string
-	DiskTotal        int64
-	DiskAvailable    int64
+	Timestamp        time.Time `json:"timestamp" yaml:"timestamp"`
+	NodeCount        int       `json:"node_count" yaml:"node_count"`
+	CPUUsage         float64   `json:"cpu_usage" yaml:"cpu_usage"`
+	CPUUsageMin      float64   `json:"cpu_usage_min" yaml:"cpu_usage_min"`
+	CPUUsageMax      float64   `json:"cpu_usage_max" yaml:"cpu_usage_max"`
+	CPUUsageMinNode  string    `json:"cpu_usage_min_node" yaml:"cpu_usage_min_node"`
+	CPUUsageMaxNode  string    `json:"cpu_usage_max_node" yaml:"cpu_usage_max_node"`
+	HeapUsage        float64   `json:"heap_usage" yaml:"heap_usage"`
+	HeapUsageMin     float64   `json:"heap_usage_min" yaml:"heap_usage_min"`
+	HeapUsageMax     float64   `json:"heap_usage_max" yaml:"heap_usage_max"`
+	HeapUsageMinNode string    `json:"heap_usage_min_node" yaml:"heap_usage_min_node"`
+	HeapUsageMaxNode string    `json:"heap_usage_max_node" yaml:"heap_usage_max_node"`
+	DiskTotal        int64     `json:"disk_total" yaml:"disk_total"`
+	DiskAvailable    int64     `json:"disk_available" yaml:"disk_available"`
+
+	DiskWatermarks *DiskWatermarks `json:"disk_watermarks,omitempty" yaml:"disk_watermarks,omitempty"`
+	NodeDisks      []NodeDiskUsage `json:"node_disks,omitempty" yaml:"node_disks,omitempty"`
 }
 
 type Performance struct {
-	Timestamp         time.Time
-	IndexTotal        int64
-	IndexTimeInMillis int64
-	QueryTotal        int64
-	QueryTimeInMillis int64
+	Timestamp         time.Time `json:"timestamp" yaml:"timestamp"`
+	IndexTotal        int64     `json:"index_total" yaml:"index_total"`
+	IndexTimeInMillis int64     `json:"index_time_in_millis" yaml:"index_time_in_millis"`
+	QueryTotal        int64     `json:"query_total" yaml:"query_total"`
+	QueryTimeInMillis int64     `json:"query_time_in_millis" yaml:"query_time_in_millis"`
 }
 
 type SegmentWarnings struct {
-	HighSegmentIndices  int
-	SmallSegmentIndices int
-	LargeSegmentIndices int
+	HighSegmentIndices  int `json:"high_segment_indices" yaml:"high_segment_indices"`
+	SmallSegmentIndices int `json:"small_segment_indices" yaml:"small_segment_indices"`
+	LargeSegmentIndices int `json:"large_segment_indices" yaml:"large_segment_indices"`
 }
 
 type ScaleWarnings struct {
-	OverScaledIndices []OverScaledIndex
-	WarningIssues     []string
+	OverScaledIndices []OverScaledIndex `json:"over_scaled_indices" yaml:"over_scaled_indices"`
+	WarningIssues     []string          `json:"warning_issues" yaml:"warning_issues"`
 }
 
 type OverScaledIndex struct {
-	Name           string
-	PrimaryShards  int
-	ReplicaShards  int
-	TotalShards    int
-	IndexSize      int64
-	DocCount       int64
-	SearchRate     float64
-	IndexRate      float64
-	WarningType    string
-	WarningMessage string
-	Recommendation ShardRecommendation
-	Severity       string
+	Name           string              `json:"name" yaml:"name"`
+	PrimaryShards  int                 `json:"primary_shards" yaml:"primary_shards"`
+	ReplicaShards  int                 `json:"replica_shards" yaml:"replica_shards"`
+	TotalShards    int                 `json:"total_shards" yaml:"total_shards"`
+	IndexSize      int64               `json:"index_size" yaml:"index_size"`
+	DocCount       int64               `json:"doc_count" yaml:"doc_count"`
+	SearchRate     float64             `json:"search_rate" yaml:"search_rate"`
+	IndexRate      float64             `json:"index_rate" yaml:"index_rate"`
+	WarningType    string              `json:"warning_type" yaml:"warning_type"`
+	WarningMessage string              `json:"warning_message" yaml:"warning_message"`
+	Recommendation ShardRecommendation `json:"recommendation" yaml:"recommendation"`
+	Severity       string              `json:"severity" yaml:"severity"`
 }
 
 type ShardRecommendation struct {
-	Recommended   int
-	MinAcceptable int
-	MaxAcceptable int
-	Confidence    float64
-	Reasoning     string
+	Recommended   int     `json:"recommended" yaml:"recommended"`
+	MinAcceptable int     `json:"min_acceptable" yaml:"min_acceptable"`
+	MaxAcceptable int     `json:"max_acceptable" yaml:"max_acceptable"`
+	Confidence    float64 `json:"confidence" yaml:"confidence"`
+	Reasoning     string  `json:"reasoning" yaml:"reasoning"`
+}
+
+// CheckReport bundles every section gathered by a single `escope check` run
+type CheckReport struct {
+	ClusterHealth       *ClusterInfo        `json:"cluster_health" yaml:"cluster_health"`
+	NodeHealths         []CheckNodeHealth   `json:"node_healths" yaml:"node_healths"`
+	ShardHealth         *ShardHealth        `json:"shard_health" yaml:"shard_health"`
+	ShardWarnings       *ShardWarnings      `json:"shard_warnings" yaml:"shard_warnings"`
+	IndexHealths        []IndexHealth       `json:"index_healths" yaml:"index_healths"`
+	ResourceUsage       *ResourceUsage      `json:"resource_usage" yaml:"resource_usage"`
+	Performance         *Performance        `json:"performance" yaml:"performance"`
+	NodeBreakdown       *NodeBreakdown      `json:"node_breakdown" yaml:"node_breakdown"`
+	SegmentWarnings     *SegmentWarnings    `json:"segment_warnings" yaml:"segment_warnings"`
+	ScaleWarnings       *ScaleWarnings      `json:"scale_warnings" yaml:"scale_warnings"`
+	ThreadPoolWarnings  *ThreadPoolWarnings `json:"thread_pool_warnings" yaml:"thread_pool_warnings"`
+	BreakerWarnings     *BreakerWarnings    `json:"breaker_warnings" yaml:"breaker_warnings"`
+	IndicesWithoutAlias []string            `json:"indices_without_alias" yaml:"indices_without_alias"`
+	RuleFindings        []RuleFinding       `json:"rule_findings" yaml:"rule_findings"`
+	Summary             *CheckSummary       `json:"summary" yaml:"summary"`
+}
+
+// RuleFinding is a rules-file check that matched; Target is the node or index name for per-item scopes
+type RuleFinding struct {
+	Rule      string `json:"rule" yaml:"rule"`
+	Severity  string `json:"severity" yaml:"severity"`
+	Scope     string `json:"scope" yaml:"scope"`
+	Target    string `json:"target" yaml:"target"`
+	Metric    string `json:"metric" yaml:"metric"`
+	Value     string `json:"value" yaml:"value"`
+	Threshold string `json:"threshold" yaml:"threshold"`
+	Message   string `json:"message" yaml:"message"`
+}
+
+// CheckSummary counts findings per severity; Status is the worst severity found
+type CheckSummary struct {
+	Status         string   `json:"status" yaml:"status"`
+	Critical       int      `json:"critical" yaml:"critical"`
+	Warning        int      `json:"warning" yaml:"warning"`
+	CriticalIssues []string `json:"critical_issues" yaml:"critical_issues"`
+	WarningIssues  []string `json:"warning_issues" yaml:"warning_issues"`
 }
@@ -7,28 +7,28 @@ import (
 )
 
 type ClusterInfo struct {
-	Timestamp                   time.Time
-	ClusterName                 string
-	Status                      string
-	NumberOfNodes               int
-	ActivePrimaryShards         int
-	ActiveShards                int
-	UnassignedShards            int
-	RelocatingShards            int
-	InitializingShards          int
-	DelayedUnassignedShards     int
-	NumberOfPendingTasks        int
-	NumberOfInFlightFetch       int
-	TaskMaxWaitingInQueueMillis int
-	ActiveShardsPercentAsNumber float64
-	TimedOut                    bool
+	Timestamp                   time.Time `json:"timestamp" yaml:"timestamp"`
+	ClusterName                 string    `json:"cluster_name" yaml:"cluster_name"`
+	Status                      string    `json:"status" yaml:"status"`
+	NumberOfNodes               int       `json:"number_of_nodes" yaml:"number_of_nodes"`
+	ActivePrimaryShards         int       `json:"active_primary_shards" yaml:"active_primary_shards"`
+	ActiveShards                int       `json:"active_shards" yaml:"active_shards"`
+	UnassignedShards            int       `json:"unassigned_shards" yaml:"unassigned_shards"`
+	RelocatingShards            int       `json:"relocating_shards" yaml:"relocating_shards"`
+	InitializingShards          int       `json:"initializing_shards" yaml:"initializing_shards"`
+	DelayedUnassignedShards     int       `json:"delayed_unassigned_shards" yaml:"delayed_unassigned_shards"`
+	NumberOfPendingTasks        int       `json:"number_of_pending_tasks" yaml:"number_of_pending_tasks"`
+	NumberOfInFlightFetch       int       `json:"number_of_in_flight_fetch" yaml:"number_of_in_flight_fetch"`
+	TaskMaxWaitingInQueueMillis int       `json:"task_max_waiting_in_queue_millis" yaml:"task_max_waiting_in_queue_millis"`
+	ActiveShardsPercentAsNumber float64   `json:"active_shards_percent_as_number" yaml:"active_shards_percent_as_number"`
+	TimedOut                    bool      `json:"timed_out" yaml:"timed_out"`
 }
 
 type NodeBreakdown struct {
-	DataNodes         int
-	MasterNodes       int
-	IngestNodes       int
-	CoordinatingNodes int
+	DataNodes         int `json:"data_nodes" yaml:"data_nodes"`
+	MasterNodes       int `json:"master_nodes" yaml:"master_nodes"`
+	IngestNodes       int `json:"ingest_nodes" yaml:"ingest_nodes"`
+	CoordinatingNodes int `json:"coordinating_nodes" yaml:"coordinating_nodes"`
 }
 
 func (n *NodeBreakdown) String() string {
@@ -75,6 +75,7 @@ type ClusterStats struct {
 	TotalMemoryBytes   int64
 	UsedMemoryBytes    int64
 	MemoryUsagePercent float64
+	CPUUsagePercent    float64 // average os.cpu.percent across nodes
 
 	// Data
 	TotalDocuments int64
@@ -84,8 +85,9 @@ type ClusterStats struct {
 	AvgShardSizeGB float64
 
 	// Version info
-	ESVersion   string
-	JVMVersions []string
+	Distribution string // elasticsearch or opensearch
+	ESVersion    string
+	JVMVersions  []string
 }
 
 func (c *ClusterStats) GetNodeBreakdown() string {
@@ -13,10 +13,6 @@ func ParseSize(sizeStr string) int64 {
 
 	sizeStr = strings.ToLower(strings.TrimSpace(sizeStr))
 
-	if strings.HasSuffix(sizeStr, "b") {
-		sizeStr = strings.TrimSuffix(sizeStr, "b")
-	}
-
 	var multiplier int64 = 1
 	if strings.HasSuffix(sizeStr, "kb") {
 		multiplier = 1024
@@ -30,6 +26,8 @@ func ParseSize(sizeStr string) int64 {
 	} else if strings.HasSuffix(sizeStr, "tb") {
 		multiplier = 1024 * 1024 * 1024 * 1024
 		sizeStr = strings.TrimSuffix(sizeStr, "tb")
+	} else {
+		sizeStr = strings.TrimSuffix(sizeStr, "b")
 	}
 
 	value, err := strconv.ParseFloat(sizeStr, 64)
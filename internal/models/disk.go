@@ -0,0 +1,206 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+)
+
+// Disk watermark levels a data node can be at, from least to most severe
+const (
+	DiskLevelOK         = "ok"
+	DiskLevelLow        = "low"
+	DiskLevelHigh       = "high"
+	DiskLevelFloodStage = "flood_stage"
+)
+
+// Watermark is one disk watermark. A percentage (or ratio) caps the used share of the disk,
+// an absolute value is the free space that must remain. MaxHeadroomBytes caps the free space
+// a percentage asks for on large disks; 0 means no cap.
+type Watermark struct {
+	Raw              string  `json:"raw" yaml:"raw"`
+	Absolute         bool    `json:"absolute" yaml:"absolute"`
+	UsedPercent      float64 `json:"used_percent" yaml:"used_percent"`
+	FreeBytes        int64   `json:"free_bytes" yaml:"free_bytes"`
+	MaxHeadroomBytes int64   `json:"max_headroom_bytes" yaml:"max_headroom_bytes"`
+}
+
+// DiskWatermarks are the effective disk-based allocation settings of a cluster.
+// Defaults is set when the settings could not be read and the Elasticsearch defaults are assumed.
+type DiskWatermarks struct {
+	Enabled    bool      `json:"enabled" yaml:"enabled"`
+	Low        Watermark `json:"low" yaml:"low"`
+	High       Watermark `json:"high" yaml:"high"`
+	FloodStage Watermark `json:"flood_stage" yaml:"flood_stage"`
+	Defaults   bool      `json:"defaults" yaml:"defaults"`
+}
+
+// DiskStatus is a node's disk measured against the watermarks. Headrooms are the free bytes
+// left before a watermark is crossed and go negative once it is.
+type DiskStatus struct {
+	Level              string `json:"level" yaml:"level"`
+	HighHeadroomBytes  int64  `json:"high_headroom_bytes" yaml:"high_headroom_bytes"`
+	FloodHeadroomBytes int64  `json:"flood_headroom_bytes" yaml:"flood_headroom_bytes"`
+	NearFloodStage     bool   `json:"near_flood_stage" yaml:"near_flood_stage"`
+}
+
+// NodeDiskUsage is the disk of one data node as reported by check
+type NodeDiskUsage struct {
+	Node           string     `json:"node" yaml:"node"`
+	TotalBytes     int64      `json:"total_bytes" yaml:"total_bytes"`
+	AvailableBytes int64      `json:"available_bytes" yaml:"available_bytes"`
+	Status         DiskStatus `json:"status" yaml:"status"`
+}
+
+// DefaultDiskWatermarks returns the Elasticsearch defaults without max headroom, which
+// only older clusters lack and which only makes the defaults stricter
+func DefaultDiskWatermarks() *DiskWatermarks {
+	low, _ := ParseWatermark(constants.DefaultDiskWatermarkLow)
+	high, _ := ParseWatermark(constants.DefaultDiskWatermarkHigh)
+	flood, _ := ParseWatermark(constants.DefaultDiskWatermarkFloodStage)
+	return &DiskWatermarks{Enabled: true, Low: low, High: high, FloodStage: flood, Defaults: true}
+}
+
+// DiskWatermarksFromSettings reads the effective watermarks from flat cluster settings
+// fetched with defaults. Transient values win over persistent ones, which win over defaults;
+// a missing or unparsable watermark keeps the Elasticsearch default.
+func DiskWatermarksFromSettings(settings map[string]interface{}) *DiskWatermarks {
+	watermarks := DefaultDiskWatermarks()
+	watermarks.Defaults = false
+
+	if enabled, ok := effectiveSetting(settings, constants.DiskThresholdEnabledSetting); ok {
+		watermarks.Enabled = !strings.EqualFold(enabled, "false")
+	}
+
+	for key, target := range map[string]*Watermark{
+		constants.DiskWatermarkLowSetting:        &watermarks.Low,
+		constants.DiskWatermarkHighSetting:       &watermarks.High,
+		constants.DiskWatermarkFloodStageSetting: &watermarks.FloodStage,
+	} {
+		if raw, ok := effectiveSetting(settings, key); ok {
+			if w, ok := ParseWatermark(raw); ok {
+				*target = w
+			}
+		}
+		if raw, ok := effectiveSetting(settings, key+constants.DiskWatermarkMaxHeadroomSuffix); ok && !target.Absolute {
+			if headroom := ParseSize(raw); headroom > 0 {
+				target.MaxHeadroomBytes = headroom
+			}
+		}
+	}
+	return watermarks
+}
+
+func effectiveSetting(settings map[string]interface{}, key string) (string, bool) {
+	for _, section := range []string{constants.ClusterSettingsTransientField, constants.ClusterSettingsPersistentField, constants.ClusterSettingsDefaultsField} {
+		values, ok := settings[section].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if value, ok := values[key].(string); ok && value != "" {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// ParseWatermark accepts "85%", a ratio such as "0.85" or a byte value such as "50gb"
+func ParseWatermark(raw string) (Watermark, bool) {
+	value := strings.ToLower(strings.TrimSpace(raw))
+	w := Watermark{Raw: strings.TrimSpace(raw)}
+
+	if strings.HasSuffix(value, "%") {
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err != nil || percent < 0 || percent > 100 {
+			return Watermark{}, false
+		}
+		w.UsedPercent = percent
+		return w, true
+	}
+	if ratio, err := strconv.ParseFloat(value, 64); err == nil {
+		if ratio < 0 || ratio > 1 {
+			return Watermark{}, false
+		}
+		w.UsedPercent = ratio * constants.HundredMultiplier
+		return w, true
+	}
+
+	bytes := ParseSize(value)
+	if bytes <= 0 && value != "0b" {
+		return Watermark{}, false
+	}
+	w.Absolute = true
+	w.FreeBytes = bytes
+	return w, true
+}
+
+// RequiredFreeBytes is the free space a disk of total bytes must keep to stay under w
+func (w Watermark) RequiredFreeBytes(total int64) int64 {
+	if w.Absolute {
+		return w.FreeBytes
+	}
+	free := int64(float64(total) * (constants.HundredMultiplier - w.UsedPercent) / constants.HundredMultiplier)
+	if w.MaxHeadroomBytes > 0 && free > w.MaxHeadroomBytes {
+		return w.MaxHeadroomBytes
+	}
+	return free
+}
+
+// String shows the watermark as configured, with its max headroom when one applies
+func (w Watermark) String() string {
+	if w.MaxHeadroomBytes > 0 {
+		return w.Raw + " (max headroom " + FormatBytes(w.MaxHeadroomBytes) + ")"
+	}
+	return w.Raw
+}
+
+// Summary lists the three watermarks on one line, noting when they are assumed or not enforced
+func (d *DiskWatermarks) Summary() string {
+	summary := "low " + d.Low.String() + ", high " + d.High.String() + ", flood stage " + d.FloodStage.String()
+	if d.Defaults {
+		summary += constants.MsgDiskWatermarksDefault
+	}
+	if !d.Enabled {
+		summary += constants.MsgDiskWatermarksOff
+	}
+	return summary
+}
+
+// HighPercent is the high watermark as a used-disk percentage, or fallback when the
+// watermark is an absolute byte value
+func (d *DiskWatermarks) HighPercent(fallback float64) float64 {
+	if d == nil || d.High.Absolute {
+		return fallback
+	}
+	return d.High.UsedPercent
+}
+
+// Evaluate measures a disk of total bytes with avail bytes free against the watermarks.
+// A node is near flood stage once it is past the high watermark or its free space above the
+// flood stage watermark drops below NearFloodStagePercent of the disk. With the disk
+// threshold decider disabled no watermark is enforced and the level stays ok.
+func (d *DiskWatermarks) Evaluate(total, avail int64) DiskStatus {
+	status := DiskStatus{
+		Level:              DiskLevelOK,
+		HighHeadroomBytes:  avail - d.High.RequiredFreeBytes(total),
+		FloodHeadroomBytes: avail - d.FloodStage.RequiredFreeBytes(total),
+	}
+	if !d.Enabled || total <= 0 {
+		return status
+	}
+
+	switch {
+	case status.FloodHeadroomBytes < 0:
+		status.Level = DiskLevelFloodStage
+	case status.HighHeadroomBytes < 0:
+		status.Level = DiskLevelHigh
+	case avail < d.Low.RequiredFreeBytes(total):
+		status.Level = DiskLevelLow
+	}
+
+	nearMargin := int64(float64(total) * constants.NearFloodStagePercent / constants.HundredMultiplier)
+	status.NearFloodStage = status.Level == DiskLevelFloodStage || status.Level == DiskLevelHigh ||
+		status.FloodHeadroomBytes < nearMargin
+	return status
+}
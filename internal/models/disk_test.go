@@ -0,0 +1,72 @@
+package models
+
+import "testing"
+
+func TestDiskWatermarksFromSettingsPrecedence(t *testing.T) {
+	settings := map[string]interface{}{
+		"transient": map[string]interface{}{
+			"cluster.routing.allocation.disk.watermark.flood_stage": "20gb",
+		},
+		"persistent": map[string]interface{}{
+			"cluster.routing.allocation.disk.watermark.high":        "0.8",
+			"cluster.routing.allocation.disk.watermark.flood_stage": "97%",
+		},
+		"defaults": map[string]interface{}{
+			"cluster.routing.allocation.disk.threshold_enabled":          "true",
+			"cluster.routing.allocation.disk.watermark.low":              "85%",
+			"cluster.routing.allocation.disk.watermark.low.max_headroom": "200GB",
+			"cluster.routing.allocation.disk.watermark.high":             "90%",
+		},
+	}
+
+	w := DiskWatermarksFromSettings(settings)
+	if !w.Enabled || w.Defaults {
+		t.Fatalf("enabled %v defaults %v", w.Enabled, w.Defaults)
+	}
+	if w.Low.UsedPercent != 85 || w.Low.MaxHeadroomBytes != 200*1024*1024*1024 {
+		t.Fatalf("low: %+v", w.Low)
+	}
+	if w.High.Absolute || w.High.UsedPercent != 80 {
+		t.Fatalf("high: %+v", w.High)
+	}
+	if !w.FloodStage.Absolute || w.FloodStage.FreeBytes != 20*1024*1024*1024 {
+		t.Fatalf("flood stage: %+v", w.FloodStage)
+	}
+}
+
+func TestDiskWatermarksEvaluate(t *testing.T) {
+	const gb = 1024 * 1024 * 1024
+	w := DefaultDiskWatermarks()
+
+	cases := []struct {
+		avail int64
+		level string
+		near  bool
+	}{
+		{avail: 50 * gb, level: DiskLevelOK},
+		{avail: 12 * gb, level: DiskLevelLow},
+		{avail: 9 * gb, level: DiskLevelHigh, near: true},
+		{avail: 4 * gb, level: DiskLevelFloodStage, near: true},
+	}
+	for _, c := range cases {
+		status := w.Evaluate(100*gb, c.avail)
+		if status.Level != c.level || status.NearFloodStage != c.near {
+			t.Fatalf("avail %d: %+v", c.avail, status)
+		}
+	}
+
+	// 90% of a 100gb disk leaves 10gb before the high watermark
+	if status := w.Evaluate(100*gb, 50*gb); status.HighHeadroomBytes != 40*gb || status.FloodHeadroomBytes != 45*gb {
+		t.Fatalf("headroom: %+v", status)
+	}
+
+	w.High.MaxHeadroomBytes = 2 * gb
+	if status := w.Evaluate(100*gb, 9*gb); status.Level != DiskLevelLow {
+		t.Fatalf("max headroom should cap the high watermark: %+v", status)
+	}
+
+	w.Enabled = false
+	if status := w.Evaluate(100*gb, 1*gb); status.Level != DiskLevelOK || status.NearFloodStage {
+		t.Fatalf("disabled: %+v", status)
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+// InvertedIndexUsage counts the accesses to each part of a field's inverted index
+type InvertedIndexUsage struct {
+	Terms           int64 `json:"terms" yaml:"terms"`
+	Postings        int64 `json:"postings" yaml:"postings"`
+	Proximity       int64 `json:"proximity" yaml:"proximity"`
+	Positions       int64 `json:"positions" yaml:"positions"`
+	TermFrequencies int64 `json:"term_frequencies" yaml:"term_frequencies"`
+	Offsets         int64 `json:"offsets" yaml:"offsets"`
+	Payloads        int64 `json:"payloads" yaml:"payloads"`
+}
+
+// FieldUsage is the access count of one field summed over every shard that reports it. Any
+// counts the searches that touched the field in any way.
+type FieldUsage struct {
+	Field         string             `json:"field" yaml:"field"`
+	Type          string             `json:"type" yaml:"type"`
+	Shards        int                `json:"shards" yaml:"shards"`
+	Any           int64              `json:"any" yaml:"any"`
+	InvertedIndex InvertedIndexUsage `json:"inverted_index" yaml:"inverted_index"`
+	DocValues     int64              `json:"doc_values" yaml:"doc_values"`
+	StoredFields  int64              `json:"stored_fields" yaml:"stored_fields"`
+	Norms         int64              `json:"norms" yaml:"norms"`
+	Points        int64              `json:"points" yaml:"points"`
+	TermVectors   int64              `json:"term_vectors" yaml:"term_vectors"`
+	KnnVectors    int64              `json:"knn_vectors" yaml:"knn_vectors"`
+}
+
+// FieldUsageReport is the field usage of an index or pattern. Usage is tracked per shard copy
+// since TrackedSinceMillis, the earliest start over all shards; a shard that relocated or
+// whose node restarted starts again from zero.
+type FieldUsageReport struct {
+	Index              string       `json:"index" yaml:"index"`
+	Shards             int          `json:"shards" yaml:"shards"`
+	TrackedSinceMillis int64        `json:"tracked_since_millis" yaml:"tracked_since_millis"`
+	Fields             []FieldUsage `json:"fields" yaml:"fields"`
+	Unused             []string     `json:"unused" yaml:"unused"`
+}
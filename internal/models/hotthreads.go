@@ -0,0 +1,32 @@
+package models
+
+// HotThread is one busy thread of a hot threads report. Stack holds the top frames of the
+// stack most of its snapshots shared, innermost first.
+type HotThread struct {
+	Node      string   `json:"node" yaml:"node"`
+	Thread    string   `json:"thread" yaml:"thread"`
+	Pool      string   `json:"pool" yaml:"pool"`
+	Percent   float64  `json:"percent" yaml:"percent"`
+	Snapshots string   `json:"snapshots" yaml:"snapshots"`
+	Stack     []string `json:"stack" yaml:"stack"`
+}
+
+// HotThreadGroup collects the hot threads that share the same top stack frames
+type HotThreadGroup struct {
+	Stack        []string `json:"stack" yaml:"stack"`
+	Threads      int      `json:"threads" yaml:"threads"`
+	Nodes        []string `json:"nodes" yaml:"nodes"`
+	Pools        []string `json:"pools" yaml:"pools"`
+	TotalPercent float64  `json:"total_percent" yaml:"total_percent"`
+}
+
+// HotThreadsReport is the parsed _nodes/hot_threads output. Raw keeps the text as returned
+// by the cluster so it can be saved next to the report.
+type HotThreadsReport struct {
+	Type      string           `json:"type" yaml:"type"`
+	Snapshots int              `json:"snapshots" yaml:"snapshots"`
+	Nodes     []string         `json:"nodes" yaml:"nodes"`
+	Threads   []HotThread      `json:"threads" yaml:"threads"`
+	Groups    []HotThreadGroup `json:"groups" yaml:"groups"`
+	Raw       string           `json:"-" yaml:"-"`
+}
@@ -6,38 +6,38 @@ import (
 )
 
 type IndexInfo struct {
-	Alias     string
-	Name      string
-	Health    string
-	Status    string
-	DocsCount string
-	StoreSize string
-	Primary   string
-	Replica   string
+	Alias     string `json:"alias" yaml:"alias"`
+	Name      string `json:"name" yaml:"name"`
+	Health    string `json:"health" yaml:"health"`
+	Status    string `json:"status" yaml:"status"`
+	DocsCount string `json:"docs_count" yaml:"docs_count"`
+	StoreSize string `json:"store_size" yaml:"store_size"`
+	Primary   string `json:"primary" yaml:"primary"`
+	Replica   string `json:"replica" yaml:"replica"`
 }
 
 type LuceneStats struct {
-	IndexName                string
-	SegmentCount             int
-	SegmentMemory            string
-	SegmentMemoryBytes       int64
-	IndexMemory              string
-	IndexMemoryBytes         int64
-	TermsMemory              string
-	TermsMemoryBytes         int64
-	StoredMemory             string
-	StoredMemoryBytes        int64
-	DocValuesMemory          string
-	DocValuesMemoryBytes     int64
-	PointsMemory             string
-	PointsMemoryBytes        int64
-	NormsMemory              string
-	NormsMemoryBytes         int64
-	FixedBitSetMemory        string
-	FixedBitSetMemoryBytes   int64
-	VersionMapMemory         string
-	VersionMapMemoryBytes    int64
-	MaxUnsafeAutoIDTimestamp int64
+	IndexName                string `json:"index_name" yaml:"index_name"`
+	SegmentCount             int    `json:"segment_count" yaml:"segment_count"`
+	SegmentMemory            string `json:"segment_memory" yaml:"segment_memory"`
+	SegmentMemoryBytes       int64  `json:"segment_memory_bytes" yaml:"segment_memory_bytes"`
+	IndexMemory              string `json:"index_memory" yaml:"index_memory"`
+	IndexMemoryBytes         int64  `json:"index_memory_bytes" yaml:"index_memory_bytes"`
+	TermsMemory              string `json:"terms_memory" yaml:"terms_memory"`
+	TermsMemoryBytes         int64  `json:"terms_memory_bytes" yaml:"terms_memory_bytes"`
+	StoredMemory             string `json:"stored_memory" yaml:"stored_memory"`
+	StoredMemoryBytes        int64  `json:"stored_memory_bytes" yaml:"stored_memory_bytes"`
+	DocValuesMemory          string `json:"doc_values_memory" yaml:"doc_values_memory"`
+	DocValuesMemoryBytes     int64  `json:"doc_values_memory_bytes" yaml:"doc_values_memory_bytes"`
+	PointsMemory             string `json:"points_memory" yaml:"points_memory"`
+	PointsMemoryBytes        int64  `json:"points_memory_bytes" yaml:"points_memory_bytes"`
+	NormsMemory              string `json:"norms_memory" yaml:"norms_memory"`
+	NormsMemoryBytes         int64  `json:"norms_memory_bytes" yaml:"norms_memory_bytes"`
+	FixedBitSetMemory        string `json:"fixed_bit_set_memory" yaml:"fixed_bit_set_memory"`
+	FixedBitSetMemoryBytes   int64  `json:"fixed_bit_set_memory_bytes" yaml:"fixed_bit_set_memory_bytes"`
+	VersionMapMemory         string `json:"version_map_memory" yaml:"version_map_memory"`
+	VersionMapMemoryBytes    int64  `json:"version_map_memory_bytes" yaml:"version_map_memory_bytes"`
+	MaxUnsafeAutoIDTimestamp int64  `json:"max_unsafe_auto_id_timestamp" yaml:"max_unsafe_auto_id_timestamp"`
 }
 
 type IndexDetailInfo struct {
@@ -49,12 +49,34 @@ type IndexDetailInfo struct {
 }
 
 type IndexStatsSnapshot struct {
-	IndexName  string
-	QueryTotal int64
-	QueryTime  int64
-	IndexTotal int64
-	IndexTime  int64
-	Timestamp  time.Time
+	IndexName    string
+	QueryTotal   int64
+	QueryTime    int64
+	IndexTotal   int64
+	IndexTime    int64
+	RefreshTotal int64
+	RefreshTime  int64
+	MergeTotal   int64
+	MergeTime    int64
+	Timestamp    time.Time
+}
+
+// IndexRates are computed from two snapshots of an index. Rates are per second, times are
+// milliseconds per operation; -1 means no operation happened in between. Without a
+// previous snapshot only the lifetime average times are known.
+type IndexRates struct {
+	SearchRate     float64
+	IndexRate      float64
+	AvgQueryTime   float64
+	AvgIndexTime   float64
+	AvgRefreshTime float64
+	AvgMergeTime   float64
+}
+
+// IndexTopInfo is one row of 'escope index --top' across indices
+type IndexTopInfo struct {
+	Name  string
+	Rates IndexRates
 }
 
 type IndexStatsCache struct {
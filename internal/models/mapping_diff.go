@@ -0,0 +1,20 @@
+package models
+
+// Kinds of mapping differences, as seen from the left index towards the right one
+const (
+	MappingFieldAdded      = "added"
+	MappingFieldRemoved    = "removed"
+	MappingTypeChanged     = "type"
+	MappingAnalyzerChanged = "analyzer"
+	MappingIndexToggled    = "index"
+)
+
+// MappingChange is one difference between two mappings. Attribute names the analyzer
+// setting (analyzer, search_analyzer or normalizer) for analyzer changes.
+type MappingChange struct {
+	Path      string `json:"path" yaml:"path"`
+	Change    string `json:"change" yaml:"change"`
+	Attribute string `json:"attribute,omitempty" yaml:"attribute,omitempty"`
+	Left      string `json:"left" yaml:"left"`
+	Right     string `json:"right" yaml:"right"`
+}
@@ -0,0 +1,48 @@
+package models
+
+// BreakerStats is one circuit breaker of a node. UsedPercent is the estimated size against
+// the limit; Tripped counts since the node started.
+type BreakerStats struct {
+	Name           string  `json:"name" yaml:"name"`
+	LimitBytes     int64   `json:"limit_bytes" yaml:"limit_bytes"`
+	EstimatedBytes int64   `json:"estimated_bytes" yaml:"estimated_bytes"`
+	UsedPercent    float64 `json:"used_percent" yaml:"used_percent"`
+	Overhead       float64 `json:"overhead" yaml:"overhead"`
+	Tripped        int64   `json:"tripped" yaml:"tripped"`
+}
+
+// CacheStats is one heap cache of a node. HitRatio is a percentage and -1 when the cache
+// tracks no lookups (fielddata) or has not been used yet.
+type CacheStats struct {
+	Name        string  `json:"name" yaml:"name"`
+	MemoryBytes int64   `json:"memory_bytes" yaml:"memory_bytes"`
+	HitCount    int64   `json:"hit_count" yaml:"hit_count"`
+	MissCount   int64   `json:"miss_count" yaml:"miss_count"`
+	HitRatio    float64 `json:"hit_ratio" yaml:"hit_ratio"`
+	Evictions   int64   `json:"evictions" yaml:"evictions"`
+}
+
+// NodeMemoryInfo combines the heap, circuit breakers and caches of one node
+type NodeMemoryInfo struct {
+	Node          string         `json:"node" yaml:"node"`
+	HeapUsedBytes int64          `json:"heap_used_bytes" yaml:"heap_used_bytes"`
+	HeapMaxBytes  int64          `json:"heap_max_bytes" yaml:"heap_max_bytes"`
+	Breakers      []BreakerStats `json:"breakers" yaml:"breakers"`
+	Caches        []CacheStats   `json:"caches" yaml:"caches"`
+}
+
+// Breaker returns the named breaker of the node
+func (n NodeMemoryInfo) Breaker(name string) (BreakerStats, bool) {
+	for _, b := range n.Breakers {
+		if b.Name == name {
+			return b, true
+		}
+	}
+	return BreakerStats{}, false
+}
+
+// BreakerWarnings lists the nodes whose parent breaker is close to its limit
+type BreakerWarnings struct {
+	CriticalIssues []string `json:"critical_issues" yaml:"critical_issues"`
+	WarningIssues  []string `json:"warning_issues" yaml:"warning_issues"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// MonitoringResult holds every sample of a continuous check and the trend analysis built from them
+type MonitoringResult struct {
+	Duration           time.Duration        `json:"-" yaml:"-"`
+	StartedAt          time.Time            `json:"started_at" yaml:"started_at"`
+	EndedAt            time.Time            `json:"ended_at" yaml:"ended_at"`
+	SampleCount        int                  `json:"sample_count" yaml:"sample_count"`
+	ClusterHealthTrend []ClusterInfo        `json:"-" yaml:"-"`
+	NodeHealthTrend    []CheckNodeHealth    `json:"-" yaml:"-"`
+	ShardHealthTrend   []ShardHealth        `json:"-" yaml:"-"`
+	IndexHealthTrend   []IndexHealth        `json:"-" yaml:"-"`
+	ResourceTrend      []ResourceUsage      `json:"-" yaml:"-"`
+	PerformanceTrend   []Performance        `json:"-" yaml:"-"`
+	ThreadPoolTrend    []ThreadPoolSnapshot `json:"-" yaml:"-"`
+	Stats              TrendStats           `json:"stats" yaml:"stats"`
+	Issues             []MonitoringIssue    `json:"issues" yaml:"issues"`
+	Recommendations    []string             `json:"recommendations" yaml:"recommendations"`
+	Summary            *CheckSummary        `json:"summary" yaml:"summary"`
+}
+
+// MonitoringSample is one tick of a continuous check, recorded as a single NDJSON line
+type MonitoringSample struct {
+	ClusterHealth ClusterInfo         `json:"cluster_health" yaml:"cluster_health"`
+	NodeHealths   []CheckNodeHealth   `json:"node_healths" yaml:"node_healths"`
+	ShardHealth   ShardHealth         `json:"shard_health" yaml:"shard_health"`
+	IndexHealths  []IndexHealth       `json:"index_healths" yaml:"index_healths"`
+	ResourceUsage *ResourceUsage      `json:"resource_usage" yaml:"resource_usage"`
+	Performance   *Performance        `json:"performance" yaml:"performance"`
+	ThreadPools   *ThreadPoolSnapshot `json:"thread_pools,omitempty" yaml:"thread_pools,omitempty"`
+}
+
+type MonitoringIssue struct {
+	Type        string    `json:"type" yaml:"type"`
+	Severity    string    `json:"severity" yaml:"severity"`
+	Description string    `json:"description" yaml:"description"`
+	Occurrences int       `json:"occurrences" yaml:"occurrences"`
+	FirstSeen   time.Time `json:"first_seen" yaml:"first_seen"`
+	LastSeen    time.Time `json:"last_seen" yaml:"last_seen"`
+}
+
+// TrendStats summarizes the per-sample cluster averages over the monitoring window
+type TrendStats struct {
+	HeapPercent    MetricSummary `json:"heap_percent" yaml:"heap_percent"`
+	CPUPercent     MetricSummary `json:"cpu_percent" yaml:"cpu_percent"`
+	DiskPercent    MetricSummary `json:"disk_percent" yaml:"disk_percent"`
+	QueryLatencyMs MetricSummary `json:"query_latency_ms" yaml:"query_latency_ms"`
+	IndexLatencyMs MetricSummary `json:"index_latency_ms" yaml:"index_latency_ms"`
+}
+
+type MetricSummary struct {
+	Samples int     `json:"samples" yaml:"samples"`
+	Min     float64 `json:"min" yaml:"min"`
+	Avg     float64 `json:"avg" yaml:"avg"`
+	Max     float64 `json:"max" yaml:"max"`
+	P95     float64 `json:"p95" yaml:"p95"`
+}
@@ -1,18 +1,43 @@
 package models
 
+import "strings"
+
 type NodeInfo struct {
-	Name        string
-	IP          string
-	Roles       []string
-	CPUPercent  string
-	MemPercent  string
-	HeapPercent string
-	DiskPercent string
-	DiskAvail   string
-	DiskTotal   string
-	Documents   int64
-	HeapUsed    string
-	HeapMax     string
+	Name        string   `json:"name" yaml:"name"`
+	IP          string   `json:"ip" yaml:"ip"`
+	Roles       []string `json:"roles" yaml:"roles"`
+	CPUPercent  string   `json:"cpu_percent" yaml:"cpu_percent"`
+	MemPercent  string   `json:"mem_percent" yaml:"mem_percent"`
+	HeapPercent string   `json:"heap_percent" yaml:"heap_percent"`
+	DiskPercent string   `json:"disk_percent" yaml:"disk_percent"`
+	DiskAvail   string   `json:"disk_avail" yaml:"disk_avail"`
+	DiskTotal   string   `json:"disk_total" yaml:"disk_total"`
+	Documents   int64    `json:"documents" yaml:"documents"`
+	HeapUsed    string   `json:"heap_used" yaml:"heap_used"`
+	HeapMax     string   `json:"heap_max" yaml:"heap_max"`
+
+	DiskTotalBytes int64       `json:"disk_total_bytes" yaml:"disk_total_bytes"`
+	DiskAvailBytes int64       `json:"disk_avail_bytes" yaml:"disk_avail_bytes"`
+	DiskWatermark  *DiskStatus `json:"disk_watermark,omitempty" yaml:"disk_watermark,omitempty"`
+}
+
+// IsDataNode reports whether the node holds shards, through the generic or a tiered data role
+func (n NodeInfo) IsDataNode() bool {
+	for _, role := range n.Roles {
+		if role == "data" || strings.HasPrefix(role, "data_") {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyWatermarks evaluates the disk of a data node against the cluster's watermarks
+func (n *NodeInfo) ApplyWatermarks(watermarks *DiskWatermarks) {
+	if watermarks == nil || !n.IsDataNode() || n.DiskTotalBytes <= 0 {
+		return
+	}
+	status := watermarks.Evaluate(n.DiskTotalBytes, n.DiskAvailBytes)
+	n.DiskWatermark = &status
 }
 
 type NodeStat struct {
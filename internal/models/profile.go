@@ -0,0 +1,38 @@
+package models
+
+// FieldValueCount is one of the most frequent values of a field
+type FieldValueCount struct {
+	Value string `json:"value" yaml:"value"`
+	Count int64  `json:"count" yaml:"count"`
+}
+
+// FieldProfile summarises one aggregatable field. Docs is the number of documents looked at:
+// every document of the index, or the sampled ones. Coverage is the percentage of them that
+// have the field; Cardinality is approximate.
+type FieldProfile struct {
+	Field         string            `json:"field" yaml:"field"`
+	Type          string            `json:"type" yaml:"type"`
+	Docs          int64             `json:"docs" yaml:"docs"`
+	DocsWithField int64             `json:"docs_with_field" yaml:"docs_with_field"`
+	Coverage      float64           `json:"coverage" yaml:"coverage"`
+	Cardinality   int64             `json:"cardinality" yaml:"cardinality"`
+	TopValues     []FieldValueCount `json:"top_values,omitempty" yaml:"top_values,omitempty"`
+	Error         string            `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// ProfileOptions bounds the work of an index profile. SampleSize is the number of documents
+// read per shard, 0 reads them all.
+type ProfileOptions struct {
+	TopValues   int
+	Concurrency int
+	SampleSize  int
+}
+
+// IndexProfile is the field profile of an index; Skipped counts the mapped fields that cannot
+// be aggregated (text, object, nested and fields inside nested objects)
+type IndexProfile struct {
+	Index      string         `json:"index" yaml:"index"`
+	SampleSize int            `json:"sample_size" yaml:"sample_size"`
+	Fields     []FieldProfile `json:"fields" yaml:"fields"`
+	Skipped    int            `json:"skipped" yaml:"skipped"`
+}
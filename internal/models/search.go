@@ -0,0 +1,95 @@
+package models
+
+// SearchShards is the _shards section of a search response
+type SearchShards struct {
+	Total      int `json:"total" yaml:"total"`
+	Successful int `json:"successful" yaml:"successful"`
+	Skipped    int `json:"skipped" yaml:"skipped"`
+	Failed     int `json:"failed" yaml:"failed"`
+}
+
+// ShardFailure is one shard that failed to run the search
+type ShardFailure struct {
+	Index  string `json:"index" yaml:"index"`
+	Shard  int    `json:"shard" yaml:"shard"`
+	Node   string `json:"node" yaml:"node"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// SearchHit is one hit with the requested fields taken from its _source; Source holds the
+// whole _source when no fields were requested
+type SearchHit struct {
+	Index  string                 `json:"index" yaml:"index"`
+	ID     string                 `json:"id" yaml:"id"`
+	Score  float64                `json:"score" yaml:"score"`
+	Fields map[string]string      `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Source map[string]interface{} `json:"source,omitempty" yaml:"source,omitempty"`
+}
+
+// ProfileNode is one query, collector or aggregation of a search profile. Breakdown holds the
+// timing components (score, next_doc, build_scorer, ...) in nanoseconds, without the counts.
+type ProfileNode struct {
+	Type        string           `json:"type" yaml:"type"`
+	Description string           `json:"description" yaml:"description"`
+	TimeNanos   int64            `json:"time_in_nanos" yaml:"time_in_nanos"`
+	Breakdown   map[string]int64 `json:"breakdown,omitempty" yaml:"breakdown,omitempty"`
+	Children    []ProfileNode    `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// ShardProfile is the profile of one shard; QueryNanos sums its top level queries
+type ShardProfile struct {
+	ID           string        `json:"id" yaml:"id"`
+	Node         string        `json:"node" yaml:"node"`
+	Index        string        `json:"index" yaml:"index"`
+	Shard        int           `json:"shard" yaml:"shard"`
+	QueryNanos   int64         `json:"query_nanos" yaml:"query_nanos"`
+	RewriteNanos int64         `json:"rewrite_nanos" yaml:"rewrite_nanos"`
+	FetchNanos   int64         `json:"fetch_nanos" yaml:"fetch_nanos"`
+	Queries      []ProfileNode `json:"queries" yaml:"queries"`
+	Collectors   []ProfileNode `json:"collectors" yaml:"collectors"`
+	Aggregations []ProfileNode `json:"aggregations" yaml:"aggregations"`
+}
+
+// SearchResult is the summary of a search response. TotalRelation is "gte" when the hit
+// count is a lower bound.
+type SearchResult struct {
+	Took          int64          `json:"took" yaml:"took"`
+	TimedOut      bool           `json:"timed_out" yaml:"timed_out"`
+	TotalHits     int64          `json:"total_hits" yaml:"total_hits"`
+	TotalRelation string         `json:"total_relation" yaml:"total_relation"`
+	Shards        SearchShards   `json:"shards" yaml:"shards"`
+	Failures      []ShardFailure `json:"failures" yaml:"failures"`
+	Hits          []SearchHit    `json:"hits" yaml:"hits"`
+	Profile       []ShardProfile `json:"profile,omitempty" yaml:"profile,omitempty"`
+}
+
+// ExplainNode is one step of a Lucene score explanation
+type ExplainNode struct {
+	Value       float64       `json:"value" yaml:"value"`
+	Description string        `json:"description" yaml:"description"`
+	Details     []ExplainNode `json:"details,omitempty" yaml:"details,omitempty"`
+}
+
+// QueryExplanation explains why a document matches a query or not; Explanation is nil when
+// the document does not exist
+type QueryExplanation struct {
+	Index       string       `json:"index" yaml:"index"`
+	ID          string       `json:"id" yaml:"id"`
+	Found       bool         `json:"found" yaml:"found"`
+	Matched     bool         `json:"matched" yaml:"matched"`
+	Explanation *ExplainNode `json:"explanation,omitempty" yaml:"explanation,omitempty"`
+}
+
+// ValidationExplanation is the rewritten Lucene query of one index, or why it is invalid
+type ValidationExplanation struct {
+	Index       string `json:"index" yaml:"index"`
+	Valid       bool   `json:"valid" yaml:"valid"`
+	Explanation string `json:"explanation,omitempty" yaml:"explanation,omitempty"`
+	Error       string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// QueryValidation is the result of _validate/query with explain and rewrite
+type QueryValidation struct {
+	Valid        bool                    `json:"valid" yaml:"valid"`
+	Explanations []ValidationExplanation `json:"explanations" yaml:"explanations"`
+}
@@ -1,7 +1,7 @@
 package models
 
 type SegmentInfo struct {
-	Index        string
-	SegmentCount int
-	SizeBytes    int64
+	Index        string `json:"index" yaml:"index"`
+	SegmentCount int    `json:"segment_count" yaml:"segment_count"`
+	SizeBytes    int64  `json:"size_bytes" yaml:"size_bytes"`
 }
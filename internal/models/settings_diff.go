@@ -0,0 +1,24 @@
+package models
+
+// SettingChange is one index setting that differs between two indices; "-" marks a setting
+// only one of them has
+type SettingChange struct {
+	Key   string `json:"key" yaml:"key"`
+	Left  string `json:"left" yaml:"left"`
+	Right string `json:"right" yaml:"right"`
+}
+
+// SettingDrift is a setting whose live value differs from the desired state; Actual is "-"
+// when the index does not set it
+type SettingDrift struct {
+	Key      string `json:"key" yaml:"key"`
+	Expected string `json:"expected" yaml:"expected"`
+	Actual   string `json:"actual" yaml:"actual"`
+}
+
+// IndexSettingsDrift is the result of verifying one index against the desired state
+type IndexSettingsDrift struct {
+	Index  string         `json:"index" yaml:"index"`
+	InSync bool           `json:"in_sync" yaml:"in_sync"`
+	Drift  []SettingDrift `json:"drift" yaml:"drift"`
+}
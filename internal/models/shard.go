@@ -1,14 +1,14 @@
 package models
 
 type ShardInfo struct {
-	Index  string
-	Shard  string
-	Prirep string
-	State  string
-	Docs   string
-	Store  string
-	IP     string
-	Node   string
+	Index  string `json:"index" yaml:"index"`
+	Shard  string `json:"shard" yaml:"shard"`
+	Prirep string `json:"prirep" yaml:"prirep"`
+	State  string `json:"state" yaml:"state"`
+	Docs   string `json:"docs" yaml:"docs"`
+	Store  string `json:"store" yaml:"store"`
+	IP     string `json:"ip" yaml:"ip"`
+	Node   string `json:"node" yaml:"node"`
 }
 
 type ShardStat struct {
@@ -27,12 +27,62 @@ type ShardDistribution struct {
 }
 
 type ShardWarnings struct {
-	UnassignedShards   int
-	RelocatingShards   int
-	InitializingShards int
-	UnbalancedShards   bool
-	UnbalancedRatio    float64
-	Recommendations    []string
-	CriticalIssues     []string
-	WarningIssues      []string
+	UnassignedShards   int      `json:"unassigned_shards" yaml:"unassigned_shards"`
+	RelocatingShards   int      `json:"relocating_shards" yaml:"relocating_shards"`
+	InitializingShards int      `json:"initializing_shards" yaml:"initializing_shards"`
+	UnbalancedShards   bool     `json:"unbalanced_shards" yaml:"unbalanced_shards"`
+	UnbalancedRatio    float64  `json:"unbalanced_ratio" yaml:"unbalanced_ratio"`
+	Recommendations    []string `json:"recommendations" yaml:"recommendations"`
+	CriticalIssues     []string `json:"critical_issues" yaml:"critical_issues"`
+	WarningIssues      []string `json:"warning_issues" yaml:"warning_issues"`
+	// UnassignedReasons explains up to MaxExplainedUnassignedShards unassigned shard copies
+	UnassignedReasons []UnassignedShardReason `json:"unassigned_reasons" yaml:"unassigned_reasons"`
+	// UnassignedCopies are the shard copies queued for explanation
+	UnassignedCopies []AllocationExplainRequest `json:"-" yaml:"-"`
+}
+
+// UnassignedShardReason is the allocation explain summary of one unassigned shard, used by check
+type UnassignedShardReason struct {
+	Index   string `json:"index" yaml:"index"`
+	Shard   int    `json:"shard" yaml:"shard"`
+	Primary bool   `json:"primary" yaml:"primary"`
+	Reason  string `json:"reason" yaml:"reason"`
+}
+
+// AllocationExplainRequest selects the shard to explain; an empty Index explains the first unassigned shard
+type AllocationExplainRequest struct {
+	Index   string
+	Shard   int
+	Primary bool
+}
+
+// AllocationExplain is the parsed cluster allocation explain response
+type AllocationExplain struct {
+	Index                string                   `json:"index" yaml:"index"`
+	Shard                int                      `json:"shard" yaml:"shard"`
+	Primary              bool                     `json:"primary" yaml:"primary"`
+	CurrentState         string                   `json:"current_state" yaml:"current_state"`
+	CurrentNode          string                   `json:"current_node" yaml:"current_node"`
+	UnassignedReason     string                   `json:"unassigned_reason" yaml:"unassigned_reason"`
+	UnassignedSince      string                   `json:"unassigned_since" yaml:"unassigned_since"`
+	UnassignedDetails    string                   `json:"unassigned_details" yaml:"unassigned_details"`
+	FailedAttempts       int                      `json:"failed_attempts" yaml:"failed_attempts"`
+	CanAllocate          string                   `json:"can_allocate" yaml:"can_allocate"`
+	Explanation          string                   `json:"explanation" yaml:"explanation"`
+	NodeDecisions        []NodeAllocationDecision `json:"node_decisions" yaml:"node_decisions"`
+	BlockingDeciderNodes map[string]int           `json:"blocking_deciders" yaml:"blocking_deciders"`
+	Hints                []string                 `json:"hints" yaml:"hints"`
+}
+
+// NodeAllocationDecision is the verdict of one node; Deciders only holds the non-YES decisions
+type NodeAllocationDecision struct {
+	NodeName string              `json:"node_name" yaml:"node_name"`
+	Decision string              `json:"decision" yaml:"decision"`
+	Deciders []AllocationDecider `json:"deciders" yaml:"deciders"`
+}
+
+type AllocationDecider struct {
+	Decider     string `json:"decider" yaml:"decider"`
+	Decision    string `json:"decision" yaml:"decision"`
+	Explanation string `json:"explanation" yaml:"explanation"`
 }
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// ThreadPoolStats is one thread pool of one node. Rejected and Completed count since the node
+// started; the deltas and rates cover the window between two snapshots and the rates are -1
+// without sampling.
+type ThreadPoolStats struct {
+	Node          string  `json:"node" yaml:"node"`
+	Pool          string  `json:"pool" yaml:"pool"`
+	Threads       int64   `json:"threads" yaml:"threads"`
+	Active        int64   `json:"active" yaml:"active"`
+	Queue         int64   `json:"queue" yaml:"queue"`
+	Largest       int64   `json:"largest" yaml:"largest"`
+	Rejected      int64   `json:"rejected" yaml:"rejected"`
+	Completed     int64   `json:"completed" yaml:"completed"`
+	RejectedDelta int64   `json:"rejected_delta" yaml:"rejected_delta"`
+	RejectedRate  float64 `json:"rejected_rate" yaml:"rejected_rate"`
+	CompletedRate float64 `json:"completed_rate" yaml:"completed_rate"`
+}
+
+// ThreadPoolSnapshot holds every pool of every node at one point in time
+type ThreadPoolSnapshot struct {
+	Timestamp time.Time         `json:"timestamp" yaml:"timestamp"`
+	Pools     []ThreadPoolStats `json:"pools" yaml:"pools"`
+}
+
+// ThreadPoolWarnings lists the pools whose rejections grew while check sampled them
+type ThreadPoolWarnings struct {
+	SampleSeconds float64           `json:"sample_seconds" yaml:"sample_seconds"`
+	GrowingPools  []ThreadPoolStats `json:"growing_pools" yaml:"growing_pools"`
+	WarningIssues []string          `json:"warning_issues" yaml:"warning_issues"`
+}
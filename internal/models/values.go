@@ -0,0 +1,39 @@
+package models
+
+// Ways escope index values aggregates a field, picked from its mapped type
+const (
+	ValuesKindTerms         = "terms"
+	ValuesKindHistogram     = "histogram"
+	ValuesKindDateHistogram = "date_histogram"
+)
+
+// ValuesOptions configures escope index values. Interval is picked from the data when empty;
+// Query is query_string syntax or a JSON query object.
+type ValuesOptions struct {
+	Top      int
+	Buckets  int
+	Interval string
+	Query    string
+	Nested   bool
+}
+
+// ValueBucket is one term or histogram bucket; Percent is its share of Total
+type ValueBucket struct {
+	Key     string  `json:"key" yaml:"key"`
+	Count   int64   `json:"count" yaml:"count"`
+	Percent float64 `json:"percent" yaml:"percent"`
+}
+
+// FieldValues is what a field holds: its top terms, or a histogram over its values. Total is
+// the number of documents matching the query (nested documents with --nested); Other counts
+// the documents of terms outside the top ones.
+type FieldValues struct {
+	Index    string        `json:"index" yaml:"index"`
+	Field    string        `json:"field" yaml:"field"`
+	Type     string        `json:"type" yaml:"type"`
+	Kind     string        `json:"kind" yaml:"kind"`
+	Interval string        `json:"interval,omitempty" yaml:"interval,omitempty"`
+	Total    int64         `json:"total" yaml:"total"`
+	Other    int64         `json:"other" yaml:"other"`
+	Buckets  []ValueBucket `json:"buckets" yaml:"buckets"`
+}
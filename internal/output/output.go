@@ -0,0 +1,301 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion is bumped whenever a field is renamed or removed from an emitted document.
+// Adding new fields is not a breaking change and keeps the version.
+const SchemaVersion = "1"
+
+type Format string
+
+const (
+	FormatTable  Format = "table"
+	FormatJSON   Format = "json"
+	FormatYAML   Format = "yaml"
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// Document kinds, used as the "kind" of the JSON/YAML envelope
+const (
+	KindIndex           = "index"
+	KindShard           = "shard"
+	KindNode            = "node"
+	KindSegments        = "segments"
+	KindLucene          = "lucene"
+	KindCheck           = "check"
+	KindCheckTrend      = "check_trend"
+	KindShardExplain    = "shard_explain"
+	KindThreadPool      = "threadpool"
+	KindNodeMemory      = "node_memory"
+	KindHotThreads      = "hot_threads"
+	KindMappingDiff     = "mapping_diff"
+	KindSettingsDiff    = "settings_diff"
+	KindSettingsDrift   = "settings_drift"
+	KindIndexProfile    = "index_profile"
+	KindFieldValues     = "field_values"
+	KindFieldUsage      = "field_usage"
+	KindSearch          = "search"
+	KindQueryExplain    = "query_explain"
+	KindQueryValidation = "query_validation"
+)
+
+var supportedFormats = []Format{FormatTable, FormatJSON, FormatYAML, FormatCSV, FormatNDJSON}
+
+// Envelope wraps JSON and YAML documents so consumers can detect schema changes
+type Envelope struct {
+	SchemaVersion string      `json:"schema_version" yaml:"schema_version"`
+	Kind          string      `json:"kind" yaml:"kind"`
+	ServedBy      string      `json:"served_by,omitempty" yaml:"served_by,omitempty"`
+	Items         interface{} `json:"items" yaml:"items"`
+}
+
+// ParseFormat validates a user supplied --output value. Empty means table.
+func ParseFormat(value string) (Format, error) {
+	v := Format(strings.ToLower(strings.TrimSpace(value)))
+	if v == "" {
+		return FormatTable, nil
+	}
+	for _, f := range supportedFormats {
+		if f == v {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported output format %q (supported: %s)", value, SupportedFormats())
+}
+
+// SupportedFormats returns the accepted --output values joined for help and error text
+func SupportedFormats() string {
+	names := make([]string, 0, len(supportedFormats))
+	for _, f := range supportedFormats {
+		names = append(names, string(f))
+	}
+	return strings.Join(names, "|")
+}
+
+// IsStructured reports whether the format replaces the human readable table
+func (f Format) IsStructured() bool {
+	return f != "" && f != FormatTable
+}
+
+// Write encodes v in the given format. v is either a slice of records (list commands)
+// or a single record (reports).
+//   - json/yaml: an Envelope with schema_version, kind and items
+//   - ndjson: one JSON object per record, no envelope
+//   - csv: one row per record with flattened snake_case columns; a single record is
+//     written as field,value rows
+func Write(w io.Writer, format Format, kind string, v interface{}) error {
+	return WriteServedBy(w, format, kind, "", v)
+}
+
+// WriteServedBy is Write with the node that answered the command recorded in the
+// json/yaml envelope. An empty servedBy leaves the field out.
+func WriteServedBy(w io.Writer, format Format, kind, servedBy string, v interface{}) error {
+	env := Envelope{SchemaVersion: SchemaVersion, Kind: kind, ServedBy: servedBy, Items: normalizeNil(v)}
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(env)
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(env); err != nil {
+			return err
+		}
+		return enc.Close()
+	case FormatNDJSON:
+		return writeNDJSON(w, v)
+	case FormatCSV:
+		return writeCSV(w, v)
+	default:
+		return fmt.Errorf("format %q is not a structured output format", format)
+	}
+}
+
+// normalizeNil turns nil slices into empty ones so consumers always get an array
+func normalizeNil(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice && rv.IsNil() {
+		return reflect.MakeSlice(rv.Type(), 0, 0).Interface()
+	}
+	return v
+}
+
+func writeNDJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return enc.Encode(v)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := enc.Encode(rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type field struct {
+	key   string
+	value string
+}
+
+func writeCSV(w io.Writer, v interface{}) error {
+	cw := csv.NewWriter(w)
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		var fields []field
+		flatten("", rv, &fields)
+		if err := cw.Write([]string{"field", "value"}); err != nil {
+			return err
+		}
+		for _, f := range fields {
+			if err := cw.Write([]string{f.key, f.value}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	var header []string
+	seen := make(map[string]bool)
+	records := make([]map[string]string, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		var fields []field
+		flatten("", rv.Index(i), &fields)
+		record := make(map[string]string, len(fields))
+		for _, f := range fields {
+			if !seen[f.key] {
+				seen[f.key] = true
+				header = append(header, f.key)
+			}
+			record[f.key] = f.value
+		}
+		records = append(records, record)
+	}
+
+	if len(header) > 0 {
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+	}
+	for _, record := range records {
+		row := make([]string, len(header))
+		for i, key := range header {
+			row[i] = record[key]
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// flatten walks v and produces dotted keys named after the json tags
+func flatten(prefix string, v reflect.Value, out *[]field) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			if prefix != "" {
+				*out = append(*out, field{key: prefix})
+			}
+			return
+		}
+		v = v.Elem()
+	}
+
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		value := ""
+		if !t.IsZero() {
+			value = t.Format(time.RFC3339)
+		}
+		*out = append(*out, field{key: prefix, value: value})
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			name, skip := jsonName(sf)
+			if skip {
+				continue
+			}
+			if sf.Anonymous && name == "" {
+				flatten(prefix, v.Field(i), out)
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+			flatten(joinKey(prefix, name), v.Field(i), out)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, k := range keys {
+			flatten(joinKey(prefix, fmt.Sprint(k.Interface())), v.MapIndex(k), out)
+		}
+	case reflect.Slice, reflect.Array:
+		if isScalarKind(v.Type().Elem().Kind()) {
+			parts := make([]string, 0, v.Len())
+			for i := 0; i < v.Len(); i++ {
+				parts = append(parts, fmt.Sprint(v.Index(i).Interface()))
+			}
+			*out = append(*out, field{key: prefix, value: strings.Join(parts, ";")})
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			flatten(joinKey(prefix, fmt.Sprintf("%d", i)), v.Index(i), out)
+		}
+	default:
+		*out = append(*out, field{key: prefix, value: fmt.Sprint(v.Interface())})
+	}
+}
+
+func jsonName(sf reflect.StructField) (string, bool) {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name := strings.Split(tag, ",")[0]
+	return name, false
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func isScalarKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Ptr, reflect.Interface:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type testRecord struct {
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+	Inner *struct {
+		Value int `json:"value"`
+	} `json:"inner"`
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat(""); err != nil || f != FormatTable {
+		t.Fatalf("empty: %v %v", f, err)
+	}
+	if f, err := ParseFormat("JSON"); err != nil || f != FormatJSON {
+		t.Fatalf("json: %v %v", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Fatal("expected error for xml")
+	}
+}
+
+func TestWriteJSONEnvelope(t *testing.T) {
+	var buf bytes.Buffer
+	var records []testRecord
+	if err := Write(&buf, FormatJSON, KindNode, records); err != nil {
+		t.Fatal(err)
+	}
+	var env map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatal(err)
+	}
+	if env["schema_version"] != SchemaVersion || env["kind"] != KindNode {
+		t.Fatalf("envelope: %v", env)
+	}
+	if items, ok := env["items"].([]interface{}); !ok || len(items) != 0 {
+		t.Fatalf("items: %v", env["items"])
+	}
+}
+
+func TestWriteServedByEnvelope(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteServedBy(&buf, FormatJSON, KindNode, "http://node-2:9200", []testRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	var env map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatal(err)
+	}
+	if env["served_by"] != "http://node-2:9200" {
+		t.Fatalf("served_by: %v", env["served_by"])
+	}
+
+	buf.Reset()
+	if err := Write(&buf, FormatJSON, KindNode, []testRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "served_by") {
+		t.Fatalf("served_by written without a node: %s", buf.String())
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	records := []testRecord{{Name: "a"}, {Name: "b"}}
+	if err := Write(&buf, FormatNDJSON, KindNode, records); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"name":"b"`) {
+		t.Fatalf("lines: %v", lines)
+	}
+}
+
+func TestWriteCSVFlattens(t *testing.T) {
+	var buf bytes.Buffer
+	rec := testRecord{Name: "n1", Roles: []string{"data", "master"}}
+	rec.Inner = &struct {
+		Value int `json:"value"`
+	}{Value: 7}
+	if err := Write(&buf, FormatCSV, KindNode, []testRecord{rec}); err != nil {
+		t.Fatal(err)
+	}
+	want := "name,roles,inner.value\nn1,data;master,7\n"
+	if buf.String() != want {
+		t.Fatalf("got %q want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := Write(&buf, FormatCSV, KindCheck, rec); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "field,value\nname,n1\n") {
+		t.Fatalf("single record: %q", buf.String())
+	}
+}
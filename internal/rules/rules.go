@@ -0,0 +1,203 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"gopkg.in/yaml.v3"
+)
+
+// Scopes select which stats document a rule's metric path is resolved against
+const (
+	ScopeCluster      = "cluster"       // GET _cluster/health
+	ScopeClusterStats = "cluster_stats" // GET _cluster/stats
+	ScopeNodes        = "nodes"         // GET _nodes/stats, evaluated per node
+	ScopeIndices      = "indices"       // GET _stats, evaluated per index (system indices skipped)
+)
+
+var (
+	validScopes      = []string{ScopeCluster, ScopeClusterStats, ScopeNodes, ScopeIndices}
+	validComparators = []string{">", ">=", "<", "<=", "==", "!="}
+	validSeverities  = []string{strings.ToLower(constants.SeverityWarning), strings.ToLower(constants.SeverityCritical)}
+)
+
+// RuleSet is the content of a rules file
+type RuleSet struct {
+	Version int    `yaml:"version"`
+	Rules   []Rule `yaml:"rules"`
+}
+
+// Rule compares the value found at Metric with Threshold and reports Message when it matches
+type Rule struct {
+	Name       string      `yaml:"name"`
+	Scope      string      `yaml:"scope"`
+	Metric     string      `yaml:"metric"`
+	Comparator string      `yaml:"comparator"`
+	Threshold  interface{} `yaml:"threshold"`
+	Severity   string      `yaml:"severity"`
+	Message    string      `yaml:"message"`
+}
+
+// Load reads and parses a rules file without validating it
+func Load(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrRulesFileRead, err)
+	}
+	return Parse(data)
+}
+
+func Parse(data []byte) (*RuleSet, error) {
+	var set RuleSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf(constants.ErrRulesFileParse, err)
+	}
+	return &set, nil
+}
+
+// Validate lints the rule set and returns every problem found, in file order
+func (s *RuleSet) Validate() []error {
+	var errs []error
+	if s.Version != 0 && s.Version != 1 {
+		errs = append(errs, fmt.Errorf("unsupported rules version %d (supported: 1)", s.Version))
+	}
+	if len(s.Rules) == 0 {
+		errs = append(errs, fmt.Errorf("no rules defined"))
+	}
+
+	seen := make(map[string]bool)
+	for i, r := range s.Rules {
+		label := fmt.Sprintf("rule #%d", i+1)
+		if r.Name != "" {
+			label = fmt.Sprintf("rule %q", r.Name)
+		}
+
+		if strings.TrimSpace(r.Name) == "" {
+			errs = append(errs, fmt.Errorf("%s: name is required", label))
+		} else if seen[r.Name] {
+			errs = append(errs, fmt.Errorf("%s: duplicate name", label))
+		}
+		seen[r.Name] = true
+
+		if !contains(validScopes, r.Scope) {
+			errs = append(errs, fmt.Errorf("%s: scope %q is invalid (valid: %s)", label, r.Scope, strings.Join(validScopes, ", ")))
+		}
+		if strings.TrimSpace(r.Metric) == "" || strings.HasPrefix(r.Metric, ".") || strings.HasSuffix(r.Metric, ".") || strings.Contains(r.Metric, "..") {
+			errs = append(errs, fmt.Errorf("%s: metric %q is not a valid dot-separated path", label, r.Metric))
+		}
+		if !contains(validComparators, r.Comparator) {
+			errs = append(errs, fmt.Errorf("%s: comparator %q is invalid (valid: %s)", label, r.Comparator, strings.Join(validComparators, " ")))
+		}
+		if r.Threshold == nil {
+			errs = append(errs, fmt.Errorf("%s: threshold is required", label))
+		} else if _, numeric := toFloat(r.Threshold); !numeric && r.Comparator != "==" && r.Comparator != "!=" {
+			errs = append(errs, fmt.Errorf("%s: non-numeric threshold %v can only be used with == or !=", label, r.Threshold))
+		}
+		if !contains(validSeverities, strings.ToLower(r.Severity)) {
+			errs = append(errs, fmt.Errorf("%s: severity %q is invalid (valid: %s)", label, r.Severity, strings.Join(validSeverities, ", ")))
+		}
+	}
+	return errs
+}
+
+// Lookup resolves a dot-separated path inside a decoded JSON document
+func Lookup(doc map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = doc
+	for _, part := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = m[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// Matches reports whether value trips the rule
+func (r Rule) Matches(value interface{}) bool {
+	if v, ok := toFloat(value); ok {
+		if t, ok := toFloat(r.Threshold); ok {
+			switch r.Comparator {
+			case ">":
+				return v > t
+			case ">=":
+				return v >= t
+			case "<":
+				return v < t
+			case "<=":
+				return v <= t
+			case "==":
+				return v == t
+			case "!=":
+				return v != t
+			}
+			return false
+		}
+	}
+
+	v := strings.ToLower(fmt.Sprint(value))
+	t := strings.ToLower(fmt.Sprint(r.Threshold))
+	switch r.Comparator {
+	case "==":
+		return v == t
+	case "!=":
+		return v != t
+	}
+	return false
+}
+
+// FormatMessage expands {target}, {metric}, {value} and {threshold} in the rule message
+func (r Rule) FormatMessage(target string, value interface{}) string {
+	msg := r.Message
+	if msg == "" {
+		msg = "{metric} {comparator} {threshold} (current: {value})"
+	}
+	replacer := strings.NewReplacer(
+		"{target}", target,
+		"{metric}", r.Metric,
+		"{comparator}", r.Comparator,
+		"{value}", FormatValue(value),
+		"{threshold}", FormatValue(r.Threshold),
+	)
+	return replacer.Replace(msg)
+}
+
+// FormatValue renders numbers without trailing zeros
+func FormatValue(value interface{}) string {
+	if f, ok := toFloat(value); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package rules
+
+import "testing"
+
+const sampleRules = `
+version: 1
+rules:
+  - name: heap-pressure
+    scope: nodes
+    metric: jvm.mem.heap_used_percent
+    comparator: ">="
+    threshold: 80
+    severity: warning
+    message: "{target} heap at {value}% (limit {threshold}%)"
+  - name: cluster-red
+    scope: cluster
+    metric: status
+    comparator: "=="
+    threshold: red
+    severity: critical
+`
+
+func TestParseAndValidate(t *testing.T) {
+	set, err := Parse([]byte(sampleRules))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := set.Validate(); len(errs) != 0 {
+		t.Fatalf("unexpected problems: %v", errs)
+	}
+	if len(set.Rules) != 2 {
+		t.Fatalf("rules: %d", len(set.Rules))
+	}
+}
+
+func TestValidateReportsProblems(t *testing.T) {
+	set, err := Parse([]byte(`
+rules:
+  - name: a
+    scope: shards
+    metric: x..y
+    comparator: "~"
+    severity: info
+  - name: a
+    scope: cluster
+    metric: status
+    comparator: ">"
+    threshold: red
+    severity: warning
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// scope, metric, comparator, threshold, severity for the first rule; duplicate + non-numeric for the second
+	if errs := set.Validate(); len(errs) != 7 {
+		t.Fatalf("problems: %d %v", len(errs), errs)
+	}
+}
+
+func TestLookupAndMatches(t *testing.T) {
+	set, _ := Parse([]byte(sampleRules))
+	doc := map[string]interface{}{
+		"name": "node-1",
+		"jvm":  map[string]interface{}{"mem": map[string]interface{}{"heap_used_percent": float64(85)}},
+	}
+	value, ok := Lookup(doc, "jvm.mem.heap_used_percent")
+	if !ok {
+		t.Fatal("metric not found")
+	}
+	heap := set.Rules[0]
+	if !heap.Matches(value) {
+		t.Fatal("85 >= 80 should match")
+	}
+	if got := heap.FormatMessage("node-1", value); got != "node-1 heap at 85% (limit 80%)" {
+		t.Fatalf("message: %q", got)
+	}
+	if _, ok := Lookup(doc, "jvm.gc"); ok {
+		t.Fatal("missing path should not resolve")
+	}
+
+	red := set.Rules[1]
+	if !red.Matches("RED") || red.Matches("green") {
+		t.Fatal("status comparison")
+	}
+}
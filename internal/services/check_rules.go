@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/rules"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+// GetRuleFindings evaluates a rules file against live stats. Each scope is fetched at most once.
+func (s *checkService) GetRuleFindings(ctx context.Context, ruleSet *rules.RuleSet) ([]models.RuleFinding, error) {
+	var findings []models.RuleFinding
+	if ruleSet == nil {
+		return findings, nil
+	}
+
+	docs := make(map[string]map[string]interface{})
+	fetch := func(scope string) (map[string]interface{}, error) {
+		if doc, ok := docs[scope]; ok {
+			return doc, nil
+		}
+		var doc map[string]interface{}
+		var err error
+		switch scope {
+		case rules.ScopeCluster:
+			doc, err = s.client.GetClusterHealth(ctx)
+			if err != nil {
+				err = fmt.Errorf(constants.ErrClusterHealthRequestFailed, err)
+			}
+		case rules.ScopeClusterStats:
+			doc, err = s.client.GetClusterStats(ctx)
+			if err != nil {
+				err = fmt.Errorf(constants.ErrClusterStatsRequestFailed, err)
+			}
+		case rules.ScopeNodes:
+			doc, err = s.client.GetNodesStats(ctx)
+			if err != nil {
+				err = fmt.Errorf(constants.ErrNodesStatsRequestFailed, err)
+			}
+		case rules.ScopeIndices:
+			doc, err = s.client.GetIndexStats(ctx, constants.EmptyString)
+			if err != nil {
+				err = fmt.Errorf(constants.ErrIndexStatsRequestFailed, err)
+			}
+		default:
+			err = fmt.Errorf("unknown rule scope %q", scope)
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs[scope] = doc
+		return doc, nil
+	}
+
+	for _, rule := range ruleSet.Rules {
+		doc, err := fetch(rule.Scope)
+		if err != nil {
+			return findings, err
+		}
+
+		for _, target := range ruleTargets(rule.Scope, doc) {
+			value, ok := rules.Lookup(target.doc, rule.Metric)
+			if !ok || !rule.Matches(value) {
+				continue
+			}
+			findings = append(findings, models.RuleFinding{
+				Rule:      rule.Name,
+				Severity:  strings.ToUpper(rule.Severity),
+				Scope:     rule.Scope,
+				Target:    target.name,
+				Metric:    rule.Metric,
+				Value:     rules.FormatValue(value),
+				Threshold: rules.FormatValue(rule.Threshold),
+				Message:   rule.FormatMessage(target.name, value),
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+type ruleTarget struct {
+	name string
+	doc  map[string]interface{}
+}
+
+// ruleTargets splits a stats document into the items a rule is evaluated against
+func ruleTargets(scope string, doc map[string]interface{}) []ruleTarget {
+	var targets []ruleTarget
+	switch scope {
+	case rules.ScopeNodes:
+		if nodes, ok := doc[constants.NodesField].(map[string]interface{}); ok {
+			for nodeID, nodeData := range nodes {
+				if node, ok := nodeData.(map[string]interface{}); ok {
+					name := util.GetStringField(node, constants.NameField)
+					if name == constants.EmptyString {
+						name = nodeID
+					}
+					targets = append(targets, ruleTarget{name: name, doc: node})
+				}
+			}
+		}
+	case rules.ScopeIndices:
+		if indices, ok := doc[constants.IndicesField].(map[string]interface{}); ok {
+			for indexName, indexData := range indices {
+				if util.IsSystemIndex(indexName) {
+					continue
+				}
+				if index, ok := indexData.(map[string]interface{}); ok {
+					targets = append(targets, ruleTarget{name: indexName, doc: index})
+				}
+			}
+		}
+	default:
+		name := util.GetStringField(doc, constants.ClusterNameField)
+		targets = append(targets, ruleTarget{name: name, doc: doc})
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].name < targets[j].name
+	})
+	return targets
+}
@@ -7,9 +7,12 @@ import (
 	"github.com/mertbahardogan/escope/internal/constants"
 	"github.com/mertbahardogan/escope/internal/interfaces"
 	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/rules"
 	"github.com/mertbahardogan/escope/internal/util"
 	"math"
+	"sort"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -18,13 +21,17 @@ type CheckService interface {
 	GetNodeHealthCheck(ctx context.Context) ([]models.CheckNodeHealth, error)
 	GetShardHealthCheck(ctx context.Context) (*models.ShardHealth, error)
 	GetShardWarningsCheck(ctx context.Context) (*models.ShardWarnings, error)
+	ExplainUnassignedShards(ctx context.Context, warnings *models.ShardWarnings)
 	GetIndexHealthCheck(ctx context.Context) ([]models.IndexHealth, error)
 	GetResourceUsageCheck(ctx context.Context) (*models.ResourceUsage, error)
 	GetPerformanceCheck(ctx context.Context) (*models.Performance, error)
 	GetNodeBreakdown(ctx context.Context) (*models.NodeBreakdown, error)
 	GetSegmentWarningsCheck(ctx context.Context) (*models.SegmentWarnings, error)
 	GetScaleWarningsCheck(ctx context.Context) (*models.ScaleWarnings, error)
+	GetThreadPoolWarningsCheck(ctx context.Context) (*models.ThreadPoolWarnings, error)
+	GetBreakerWarningsCheck(ctx context.Context) (*models.BreakerWarnings, error)
 	GetIndicesWithoutAliasInfo(ctx context.Context) ([]string, error)
+	GetRuleFindings(ctx context.Context, ruleSet *rules.RuleSet) ([]models.RuleFinding, error)
 }
 
 type checkService struct {
@@ -32,6 +39,9 @@ type checkService struct {
 	nodeService     NodeService
 	segmentsService SegmentsService
 	indexService    IndexService
+	shardService    ShardService
+	threadPools     ThreadPoolService
+	memoryService   MemoryService
 }
 
 type indexTrafficRates struct {
@@ -45,6 +55,9 @@ func NewCheckService(client interfaces.ElasticClient) CheckService {
 		nodeService:     NewNodeService(client),
 		segmentsService: NewSegmentsService(client),
 		indexService:    NewIndexService(client),
+		shardService:    NewShardService(client),
+		threadPools:     NewThreadPoolService(client),
+		memoryService:   NewMemoryService(client),
 	}
 }
 
@@ -185,6 +198,7 @@ func (s *checkService) GetShardWarningsCheck(ctx context.Context) (*models.Shard
 	}
 
 	nodeShardCounts := make(map[string]int)
+	var unassigned []models.AllocationExplainRequest
 	for _, shard := range shardsList {
 		state := util.GetStringField(shard, constants.StateField)
 		node := util.GetStringField(shard, constants.NodeFieldKey)
@@ -193,6 +207,7 @@ func (s *checkService) GetShardWarningsCheck(ctx context.Context) (*models.Shard
 		switch state {
 		case constants.ShardStateUnassigned:
 			warnings.UnassignedShards++
+			unassigned = appendUnassignedRequest(unassigned, shard)
 		case constants.ShardStateRelocating:
 			warnings.RelocatingShards++
 		case constants.ShardStateInitializing:
@@ -211,6 +226,7 @@ func (s *checkService) GetShardWarningsCheck(ctx context.Context) (*models.Shard
 			fmt.Sprintf(constants.MsgUnassignedShards, warnings.UnassignedShards))
 		warnings.Recommendations = append(warnings.Recommendations,
 			fmt.Sprintf(constants.MsgInvestigateUnassigned, warnings.UnassignedShards))
+		warnings.UnassignedCopies = unassigned
 	}
 
 	if warnings.RelocatingShards > 0 {
@@ -260,6 +276,74 @@ func (s *checkService) GetShardWarningsCheck(ctx context.Context) (*models.Shard
 	return warnings, nil
 }
 
+// appendUnassignedRequest adds the shard copy unless the same index, shard number and type is
+// already queued; the explain API cannot tell replicas of one shard apart anyway
+func appendUnassignedRequest(requests []models.AllocationExplainRequest, shard map[string]interface{}) []models.AllocationExplainRequest {
+	shardNum, err := strconv.Atoi(util.GetStringField(shard, constants.ShardField))
+	if err != nil || len(requests) >= constants.MaxExplainedUnassignedShards {
+		return requests
+	}
+	req := models.AllocationExplainRequest{
+		Index:   util.GetStringField(shard, constants.IndexField),
+		Shard:   shardNum,
+		Primary: util.GetStringField(shard, constants.PrirepField2) == constants.PrimaryShortString,
+	}
+	for _, r := range requests {
+		if r == req {
+			return requests
+		}
+	}
+	return append(requests, req)
+}
+
+// ExplainUnassignedShards adds the allocation explain summary of each shard copy queued by
+// GetShardWarningsCheck, at most UnassignedExplainConcurrency calls at a time. It runs apart
+// from the shard warnings check so that slow explain calls on a degraded cluster cannot lose
+// the whole section: ctx is the budget of the explain calls, and the summaries that finish
+// within it are kept. A failing call only drops that shard's summary.
+func (s *checkService) ExplainUnassignedShards(ctx context.Context, warnings *models.ShardWarnings) {
+	if warnings == nil || len(warnings.UnassignedCopies) == 0 {
+		return
+	}
+	reasons := make([]*models.UnassignedShardReason, len(warnings.UnassignedCopies))
+	slots := make(chan struct{}, constants.UnassignedExplainConcurrency)
+	var wg sync.WaitGroup
+	for i, req := range warnings.UnassignedCopies {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, req models.AllocationExplainRequest) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			explain, err := s.shardService.ExplainAllocation(ctx, &req)
+			if err != nil {
+				return
+			}
+			reasons[i] = &models.UnassignedShardReason{
+				Index:   req.Index,
+				Shard:   req.Shard,
+				Primary: req.Primary,
+				Reason:  summarizeAllocation(explain),
+			}
+		}(i, req)
+	}
+	wg.Wait()
+
+	for _, r := range reasons {
+		if r != nil {
+			warnings.UnassignedReasons = append(warnings.UnassignedReasons, *r)
+		}
+	}
+	if len(warnings.UnassignedReasons) > 0 {
+		first := warnings.UnassignedReasons[0]
+		primaryFlag := ""
+		if first.Primary {
+			primaryFlag = " --primary"
+		}
+		warnings.Recommendations = append(warnings.Recommendations,
+			fmt.Sprintf(constants.MsgExplainUnassigned, first.Index, first.Shard, primaryFlag))
+	}
+}
+
 func (s *checkService) GetIndexHealthCheck(ctx context.Context) ([]models.IndexHealth, error) {
 	indicesData, err := s.client.GetIndices(ctx)
 	if err != nil {
@@ -297,6 +381,12 @@ func (s *checkService) GetResourceUsageCheck(ctx context.Context) (*models.Resou
 		Timestamp: time.Now(),
 	}
 
+	watermarks, err := s.nodeService.GetDiskWatermarks(ctx)
+	if err != nil {
+		watermarks = models.DefaultDiskWatermarks()
+	}
+	usage.DiskWatermarks = watermarks
+
 	type nodeMetric struct {
 		cpuUsage  float64
 		heapUsage float64
@@ -350,11 +440,18 @@ func (s *checkService) GetResourceUsageCheck(ctx context.Context) (*models.Resou
 
 				if fs, ok := node[constants.FSField].(map[string]interface{}); ok {
 					if total, ok := fs[constants.TotalField].(map[string]interface{}); ok {
+						disk := models.NodeDiskUsage{Node: metric.nodeName}
 						if totalBytes, ok := total[constants.TotalInBytesField].(float64); ok {
 							usage.DiskTotal += int64(totalBytes)
+							disk.TotalBytes = int64(totalBytes)
 						}
 						if availableBytes, ok := total[constants.AvailableInBytesField].(float64); ok {
 							usage.DiskAvailable += int64(availableBytes)
+							disk.AvailableBytes = int64(availableBytes)
+						}
+						if disk.TotalBytes > 0 {
+							disk.Status = watermarks.Evaluate(disk.TotalBytes, disk.AvailableBytes)
+							usage.NodeDisks = append(usage.NodeDisks, disk)
 						}
 					}
 				}
@@ -486,6 +583,49 @@ func (s *checkService) GetSegmentWarningsCheck(ctx context.Context) (*models.Seg
 	return warnings, nil
 }
 
+// GetThreadPoolWarningsCheck takes two thread pool snapshots ThreadPoolCheckSampleSeconds
+// apart and warns about every pool whose rejections grew in between. Rejections counted
+// before the window are history and not reported.
+func (s *checkService) GetThreadPoolWarningsCheck(ctx context.Context) (*models.ThreadPoolWarnings, error) {
+	first, err := s.threadPools.GetThreadPoolSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(constants.ThreadPoolCheckSampleSeconds * time.Second):
+	}
+	second, err := s.threadPools.GetThreadPoolSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := &models.ThreadPoolWarnings{
+		SampleSeconds: second.Timestamp.Sub(first.Timestamp).Seconds(),
+		GrowingPools:  make([]models.ThreadPoolStats, 0),
+		WarningIssues: make([]string, 0),
+	}
+	for _, pool := range s.threadPools.CompareThreadPools(first, second) {
+		if pool.RejectedDelta > 0 {
+			warnings.GrowingPools = append(warnings.GrowingPools, pool)
+		}
+	}
+	sort.Slice(warnings.GrowingPools, func(i, j int) bool {
+		return warnings.GrowingPools[i].RejectedDelta > warnings.GrowingPools[j].RejectedDelta
+	})
+	for _, pool := range warnings.GrowingPools {
+		warnings.WarningIssues = append(warnings.WarningIssues, fmt.Sprintf(constants.MsgThreadPoolRejections,
+			pool.Pool, pool.Node, pool.RejectedDelta, warnings.SampleSeconds, pool.RejectedRate))
+	}
+	return warnings, nil
+}
+
+// GetBreakerWarningsCheck flags nodes whose parent circuit breaker is close to its limit
+func (s *checkService) GetBreakerWarningsCheck(ctx context.Context) (*models.BreakerWarnings, error) {
+	return s.memoryService.GetBreakerWarnings(ctx)
+}
+
 func (s *checkService) GetScaleWarningsCheck(ctx context.Context) (*models.ScaleWarnings, error) {
 	indicesData, err := s.client.GetIndices(ctx)
 	if err != nil {
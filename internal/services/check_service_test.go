@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mertbahardogan/escope/internal/models"
+)
+
+// slowExplainShardService answers allocation explain at once, except for shard 1, which
+// only returns when the context is done
+type slowExplainShardService struct {
+	ShardService
+}
+
+func (s slowExplainShardService) ExplainAllocation(ctx context.Context, req *models.AllocationExplainRequest) (*models.AllocationExplain, error) {
+	if req.Shard == 1 {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &models.AllocationExplain{Index: req.Index, Shard: req.Shard, UnassignedReason: "NODE_LEFT"}, nil
+}
+
+func TestExplainUnassignedShardsKeepsFinishedCalls(t *testing.T) {
+	service := &checkService{shardService: slowExplainShardService{}}
+	warnings := &models.ShardWarnings{UnassignedCopies: []models.AllocationExplainRequest{
+		{Index: "logs", Shard: 0, Primary: true}, {Index: "logs", Shard: 1}, {Index: "logs", Shard: 2},
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	service.ExplainUnassignedShards(ctx, warnings)
+
+	if len(warnings.UnassignedReasons) != 2 || warnings.UnassignedReasons[0].Shard != 0 || warnings.UnassignedReasons[1].Shard != 2 {
+		t.Fatalf("reasons: %+v", warnings.UnassignedReasons)
+	}
+	if len(warnings.Recommendations) != 1 {
+		t.Fatalf("recommendations: %v", warnings.Recommendations)
+	}
+}
@@ -117,6 +117,10 @@ func (s *clusterService) GetClusterStats(ctx context.Context) (*models.ClusterSt
 	s.parseNodeInfo(clusterStatsData, stats)
 	s.parseIndicesData(clusterStatsData, stats)
 	s.parseVersionInfo(clusterStatsData, stats)
+	// The distribution is informational, a failing root endpoint must not hide the stats
+	if info, err := s.client.GetServerInfo(ctx); err == nil {
+		s.parseServerInfo(info, stats)
+	}
 	s.parseResourceUsage(nodesStatsData, stats)
 	s.calculatePercentages(stats)
 
@@ -219,12 +223,25 @@ func (s *clusterService) parseVersionInfo(clusterStatsData map[string]interface{
 	}
 }
 
+func (s *clusterService) parseServerInfo(info map[string]interface{}, stats *models.ClusterStats) {
+	version, ok := info["version"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	stats.Distribution = util.GetStringField(version, "distribution")
+	if number := util.GetStringField(version, "number"); number != "" {
+		stats.ESVersion = number
+	}
+}
+
 func (s *clusterService) parseResourceUsage(nodesStatsData map[string]interface{}, stats *models.ClusterStats) {
 	nodes, ok := nodesStatsData["nodes"].(map[string]interface{})
 	if !ok {
 		return
 	}
 
+	var cpuTotal float64
+	cpuNodes := 0
 	for _, nodeData := range nodes {
 		node, ok := nodeData.(map[string]interface{})
 		if !ok {
@@ -234,7 +251,27 @@ func (s *clusterService) parseResourceUsage(nodesStatsData map[string]interface{
 		s.parseJVMMemory(node, stats)
 		s.parseSystemMemory(node, stats)
 		s.parseDiskUsage(node, stats)
+		if cpu, ok := nodeCPUPercent(node); ok {
+			cpuTotal += cpu
+			cpuNodes++
+		}
+	}
+	if cpuNodes > 0 {
+		stats.CPUUsagePercent = cpuTotal / float64(cpuNodes)
+	}
+}
+
+func nodeCPUPercent(node map[string]interface{}) (float64, bool) {
+	os, ok := node["os"].(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+	cpu, ok := os["cpu"].(map[string]interface{})
+	if !ok {
+		return 0, false
 	}
+	percent, ok := cpu["percent"].(float64)
+	return percent, ok
 }
 
 func (s *clusterService) parseJVMMemory(node map[string]interface{}, stats *models.ClusterStats) {
@@ -6,6 +6,7 @@ import (
 	"github.com/mertbahardogan/escope/internal/config"
 	"github.com/mertbahardogan/escope/internal/connection"
 	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/elastic"
 	"os"
 )
 
@@ -22,6 +23,8 @@ type ConfigService interface {
 	SetConnectionTimeout(timeout int) error
 	GetConnectionTimeout() (int, error)
 	GetAppConfig() (config.AppConfig, error)
+	EncryptSecrets(passphrase string) (int, error)
+	DecryptSecrets() (int, error)
 }
 
 type configService struct{}
@@ -82,10 +85,31 @@ func (s *configService) ClearConfig() error {
 }
 
 func (s *configService) ValidateConfig(cfg config.ConnectionConfig) error {
+	cfg, err := config.ResolveSecrets(cfg)
+	if err != nil {
+		return err
+	}
+
 	if cfg.Host == constants.EmptyString {
 		return fmt.Errorf(constants.ErrHostIsRequired)
 	}
 
+	if cfg.APIKey != constants.EmptyString && cfg.BearerToken != constants.EmptyString {
+		return fmt.Errorf(constants.ErrConflictingTokenAuth)
+	}
+
+	if err := elastic.ValidateType(cfg.Type); err != nil {
+		return err
+	}
+	if cfg.AWSRegion != constants.EmptyString {
+		if cfg.Type != elastic.TypeOpenSearch {
+			return fmt.Errorf(constants.ErrSigV4RequiresOpenSearch)
+		}
+		if _, err := elastic.LoadAWSCredentials(cfg.AWSProfile); err != nil {
+			return err
+		}
+	}
+
 	if cfg.Secure {
 		if cfg.Username == constants.EmptyString {
 			return fmt.Errorf(constants.ErrUsernameRequired)
@@ -95,18 +119,17 @@ func (s *configService) ValidateConfig(cfg config.ConnectionConfig) error {
 		}
 	}
 
-	connConfig := connection.Config{
-		Host:     cfg.Host,
-		Username: cfg.Username,
-		Password: cfg.Password,
-		Secure:   cfg.Secure,
-	}
+	connConfig := connection.FromSavedConfig(cfg)
 
 	timeout := constants.DefaultTimeout
 	if appTimeout, err := s.GetConnectionTimeout(); err == nil {
 		timeout = appTimeout
 	}
 
+	if err := connection.CheckTLS(connConfig, timeout); err != nil {
+		return fmt.Errorf(constants.ErrTLSCheckFailed, err)
+	}
+
 	if err := connection.TestConnection(connConfig, timeout); err != nil {
 		return fmt.Errorf(constants.ErrConnectionFailed2, err)
 	}
@@ -166,3 +189,19 @@ func (s *configService) ClearActiveHost() error {
 	}
 	return nil
 }
+
+func (s *configService) EncryptSecrets(passphrase string) (int, error) {
+	count, err := config.Encrypt(passphrase)
+	if err != nil {
+		return 0, fmt.Errorf(constants.ErrFailedToEncryptConfig, err)
+	}
+	return count, nil
+}
+
+func (s *configService) DecryptSecrets() (int, error) {
+	count, err := config.Decrypt()
+	if err != nil {
+		return 0, fmt.Errorf(constants.ErrFailedToDecryptConfig, err)
+	}
+	return count, nil
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/interfaces"
+	"github.com/mertbahardogan/escope/internal/models"
+)
+
+// containerTypes hold no data structures of their own; their usage shows on the fields below
+// them, or on the target of an alias
+var containerTypes = map[string]bool{"object": true, "nested": true, "alias": true}
+
+type FieldUsageService interface {
+	GetFieldUsage(ctx context.Context, indexName string) (*models.FieldUsageReport, error)
+}
+
+type fieldUsageService struct {
+	client interfaces.ElasticClient
+}
+
+func NewFieldUsageService(client interfaces.ElasticClient) FieldUsageService {
+	return &fieldUsageService{
+		client: client,
+	}
+}
+
+// GetFieldUsage sums the field usage stats of every shard of the matching indices and lists
+// the mapped fields that no shard has accessed. Metadata fields (_id, _source, ...) are left
+// out.
+func (s *fieldUsageService) GetFieldUsage(ctx context.Context, indexName string) (*models.FieldUsageReport, error) {
+	usageData, err := s.client.GetFieldUsageStats(ctx, indexName)
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrFieldUsageFailed, err)
+	}
+	mappingData, err := s.client.GetIndexMapping(ctx, indexName)
+	if err != nil {
+		return nil, fmt.Errorf("mapping request failed: %w", err)
+	}
+	report := parseFieldUsage(usageData, mappedFieldTypes(mappingData))
+	report.Index = indexName
+	return report, nil
+}
+
+// mappedFieldTypes returns the type of every mapped field over all indices of the mapping
+// response, multi-fields included
+func mappedFieldTypes(mappingData map[string]interface{}) map[string]string {
+	types := make(map[string]string)
+	for _, indexData := range mappingData {
+		indexMap, ok := indexData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		mappings, _ := indexMap["mappings"].(map[string]interface{})
+		properties, ok := mappings["properties"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, field := range extractFields(properties, "", 0, true) {
+			if _, seen := types[field.Path]; !seen || types[field.Path] == constants.DashString {
+				types[field.Path] = field.Type
+			}
+		}
+	}
+	return types
+}
+
+func parseFieldUsage(usageData map[string]interface{}, mapped map[string]string) *models.FieldUsageReport {
+	report := &models.FieldUsageReport{
+		Fields: make([]models.FieldUsage, 0),
+		Unused: make([]string, 0),
+	}
+	usage := make(map[string]*models.FieldUsage)
+
+	for indexName, indexData := range usageData {
+		if indexName == "_shards" {
+			continue
+		}
+		indexMap, ok := indexData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		shards, _ := indexMap["shards"].([]interface{})
+		for _, sh := range shards {
+			shard, ok := sh.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			report.Shards++
+			if started := int64Field(shard, "tracking_started_at_millis"); started > 0 &&
+				(report.TrackedSinceMillis == 0 || started < report.TrackedSinceMillis) {
+				report.TrackedSinceMillis = started
+			}
+			stats, _ := shard["stats"].(map[string]interface{})
+			fields, _ := stats["fields"].(map[string]interface{})
+			for name, data := range fields {
+				fieldStats, ok := data.(map[string]interface{})
+				if !ok || strings.HasPrefix(name, "_") {
+					continue
+				}
+				fu, ok := usage[name]
+				if !ok {
+					fu = &models.FieldUsage{Field: name, Type: constants.DashString}
+					if t, ok := mapped[name]; ok {
+						fu.Type = t
+					}
+					usage[name] = fu
+				}
+				addFieldUsage(fu, fieldStats)
+			}
+		}
+	}
+
+	for _, fu := range usage {
+		report.Fields = append(report.Fields, *fu)
+	}
+	sort.Slice(report.Fields, func(i, j int) bool {
+		if report.Fields[i].Any != report.Fields[j].Any {
+			return report.Fields[i].Any > report.Fields[j].Any
+		}
+		return report.Fields[i].Field < report.Fields[j].Field
+	})
+
+	for path, fieldType := range mapped {
+		if containerTypes[fieldType] {
+			continue
+		}
+		if fu, ok := usage[path]; !ok || fu.Any == 0 {
+			report.Unused = append(report.Unused, path)
+		}
+	}
+	sort.Strings(report.Unused)
+	return report
+}
+
+func addFieldUsage(fu *models.FieldUsage, stats map[string]interface{}) {
+	fu.Shards++
+	fu.Any += int64Field(stats, "any")
+	fu.DocValues += int64Field(stats, "doc_values")
+	fu.StoredFields += int64Field(stats, "stored_fields")
+	fu.Norms += int64Field(stats, "norms")
+	fu.Points += int64Field(stats, "points")
+	fu.TermVectors += int64Field(stats, "term_vectors")
+	fu.KnnVectors += int64Field(stats, "knn_vectors")
+	if inverted, ok := stats["inverted_index"].(map[string]interface{}); ok {
+		fu.InvertedIndex.Terms += int64Field(inverted, "terms")
+		fu.InvertedIndex.Postings += int64Field(inverted, "postings")
+		fu.InvertedIndex.Proximity += int64Field(inverted, "proximity")
+		fu.InvertedIndex.Positions += int64Field(inverted, "positions")
+		fu.InvertedIndex.TermFrequencies += int64Field(inverted, "term_frequencies")
+		fu.InvertedIndex.Offsets += int64Field(inverted, "offsets")
+		fu.InvertedIndex.Payloads += int64Field(inverted, "payloads")
+	}
+}
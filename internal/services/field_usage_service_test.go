@@ -0,0 +1,52 @@
+package services
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseFieldUsage(t *testing.T) {
+	var mapping, usage map[string]interface{}
+	rawMapping := `{"logs-1": {"mappings": {"properties": {
+	  "message": {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+	  "status": {"type": "integer"},
+	  "user": {"properties": {"id": {"type": "keyword"}, "legacy_ref": {"type": "keyword"}}},
+	  "user_id": {"type": "alias", "path": "user.id"}}}},
+	  "logs-2": {"mappings": {"properties": {"status": {"type": "integer"}, "trace": {"type": "keyword"}}}}}`
+	rawUsage := `{"_shards": {"total": 2, "successful": 2, "failed": 0},
+	  "logs-1": {"shards": [{"tracking_started_at_millis": 2000, "stats": {"fields": {
+	    "_id": {"any": 9, "stored_fields": 9},
+	    "message": {"any": 3, "inverted_index": {"terms": 3, "postings": 3, "positions": 2}, "norms": 3},
+	    "status": {"any": 2, "points": 2, "doc_values": 1},
+	    "user.id": {"any": 1, "doc_values": 1}}}}]},
+	  "logs-2": {"shards": [{"tracking_started_at_millis": 1000, "stats": {"fields": {
+	    "status": {"any": 4, "points": 4},
+	    "trace": {"any": 0}}}}]}}`
+	if err := json.Unmarshal([]byte(rawMapping), &mapping); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(rawUsage), &usage); err != nil {
+		t.Fatal(err)
+	}
+
+	report := parseFieldUsage(usage, mappedFieldTypes(mapping))
+	if report.Shards != 2 || report.TrackedSinceMillis != 1000 {
+		t.Fatalf("report: %+v", report)
+	}
+	if len(report.Fields) != 4 || report.Fields[0].Field != "status" {
+		t.Fatalf("fields must be sorted by access and skip metadata: %+v", report.Fields)
+	}
+	status := report.Fields[0]
+	if status.Any != 6 || status.Points != 6 || status.DocValues != 1 || status.Shards != 2 || status.Type != "integer" {
+		t.Fatalf("status: %+v", status)
+	}
+	if report.Fields[1].InvertedIndex.Terms != 3 || report.Fields[1].Norms != 3 {
+		t.Fatalf("message: %+v", report.Fields[1])
+	}
+
+	want := []string{"message.keyword", "trace", "user.legacy_ref"}
+	if !reflect.DeepEqual(report.Unused, want) {
+		t.Fatalf("unused: got %v, want %v", report.Unused, want)
+	}
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/interfaces"
+	"github.com/mertbahardogan/escope/internal/models"
+)
+
+var (
+	// 89.3% [cpu=89.3%, other=0.0%] (446.5ms out of 500ms) cpu usage by thread 'elasticsearch[node-1][search][T#3]'
+	hotThreadLine = regexp.MustCompile(`^\s*([\d.]+)%\s+(?:\[[^\]]*\]\s+)?\([^)]*\)\s+\w+ usage by thread '([^']+)'`)
+	// 10/10 snapshots sharing following 29 elements
+	hotThreadSnapshotLine = regexp.MustCompile(`^\s*(\d+/\d+) snapshots sharing following \d+ elements`)
+	hotThreadNodeName     = regexp.MustCompile(`^:::\s*\{([^}]*)\}`)
+	hotThreadPoolName     = regexp.MustCompile(`\]\[([^\]]+)\]\[T#\d+\]`)
+)
+
+type HotThreadsService interface {
+	GetHotThreads(ctx context.Context, node, threadType string, snapshots int) (*models.HotThreadsReport, error)
+}
+
+type hotThreadsService struct {
+	client interfaces.ElasticClient
+}
+
+func NewHotThreadsService(client interfaces.ElasticClient) HotThreadsService {
+	return &hotThreadsService{
+		client: client,
+	}
+}
+
+// GetHotThreads captures hot threads of every node, or of the nodes matching node, and
+// parses them into threads and groups of threads sharing a stack
+func (s *hotThreadsService) GetHotThreads(ctx context.Context, node, threadType string, snapshots int) (*models.HotThreadsReport, error) {
+	var nodes []string
+	if node != "" {
+		nodes = []string{node}
+	}
+	raw, err := s.client.GetHotThreads(ctx, nodes, threadType, snapshots)
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrHotThreadsFailed, err)
+	}
+
+	report := parseHotThreads(raw, constants.HotThreadsTopFrames)
+	report.Type = threadType
+	report.Snapshots = snapshots
+	return report, nil
+}
+
+// parseHotThreads reads the plain text report. Only the first stack of each thread is kept:
+// the API lists the stack shared by most snapshots first.
+func parseHotThreads(raw string, topFrames int) *models.HotThreadsReport {
+	report := &models.HotThreadsReport{
+		Nodes:   make([]string, 0),
+		Threads: make([]models.HotThread, 0),
+		Raw:     raw,
+	}
+
+	var node string
+	var current *models.HotThread
+	inStack := false
+	flush := func() {
+		if current != nil {
+			report.Threads = append(report.Threads, *current)
+			current = nil
+		}
+		inStack = false
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(raw))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if m := hotThreadNodeName.FindStringSubmatch(trimmed); m != nil {
+			flush()
+			node = m[1]
+			report.Nodes = append(report.Nodes, node)
+			continue
+		}
+		if m := hotThreadLine.FindStringSubmatch(line); m != nil {
+			flush()
+			percent, _ := strconv.ParseFloat(m[1], 64)
+			current = &models.HotThread{Node: node, Thread: m[2], Percent: percent, Stack: make([]string, 0, topFrames)}
+			if pool := hotThreadPoolName.FindStringSubmatch(m[2]); pool != nil {
+				current.Pool = pool[1]
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if m := hotThreadSnapshotLine.FindStringSubmatch(line); m != nil || trimmed == "unique snapshot" {
+			// Later stacks of the same thread are rarer samples
+			inStack = current.Snapshots == ""
+			if m != nil {
+				current.Snapshots = m[1]
+			} else if current.Snapshots == "" {
+				current.Snapshots = "1"
+			}
+			continue
+		}
+		if trimmed == "" {
+			inStack = false
+			continue
+		}
+		if inStack && len(current.Stack) < topFrames {
+			current.Stack = append(current.Stack, trimFrame(trimmed))
+		}
+	}
+	flush()
+
+	sort.SliceStable(report.Threads, func(i, j int) bool {
+		return report.Threads[i].Percent > report.Threads[j].Percent
+	})
+	report.Groups = groupHotThreads(report.Threads)
+	return report
+}
+
+// trimFrame drops the module prefix of a frame: app//org.Foo.bar(Foo.java:1) and
+// java.base@21/java.lang.Thread.run(Thread.java:1583) become org.Foo.bar(...) and java.lang...
+func trimFrame(frame string) string {
+	head := frame
+	if i := strings.Index(frame, "("); i >= 0 {
+		head = frame[:i]
+	}
+	if i := strings.LastIndex(head, "/"); i >= 0 {
+		return frame[i+1:]
+	}
+	return frame
+}
+
+// groupHotThreads groups threads by their top frames, busiest group first
+func groupHotThreads(threads []models.HotThread) []models.HotThreadGroup {
+	groups := make([]models.HotThreadGroup, 0)
+	index := make(map[string]int)
+	nodes := make(map[string]map[string]bool)
+	pools := make(map[string]map[string]bool)
+
+	for _, t := range threads {
+		if len(t.Stack) == 0 {
+			continue
+		}
+		key := strings.Join(t.Stack, "\n")
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, models.HotThreadGroup{Stack: t.Stack})
+			nodes[key] = make(map[string]bool)
+			pools[key] = make(map[string]bool)
+		}
+		groups[i].Threads++
+		groups[i].TotalPercent += t.Percent
+		nodes[key][t.Node] = true
+		if t.Pool != "" {
+			pools[key][t.Pool] = true
+		}
+	}
+
+	for i := range groups {
+		key := strings.Join(groups[i].Stack, "\n")
+		groups[i].Nodes = sortedKeys(nodes[key])
+		groups[i].Pools = sortedKeys(pools[key])
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].TotalPercent > groups[j].TotalPercent
+	})
+	return groups
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,55 @@
+package services
+
+import "testing"
+
+const hotThreadsSample = `::: {data-1}{Xk2}{eph}{10.0.0.1}{10.0.0.1:9300}{dim}
+   Hot threads at 2025-01-01T10:00:00.000Z, interval=500ms, busiestThreads=3, ignoreIdleThreads=true:
+
+   89.3% [cpu=89.3%, other=0.0%] (446.5ms out of 500ms) cpu usage by thread 'elasticsearch[data-1][search][T#3]'
+     10/10 snapshots sharing following 29 elements
+       app//org.apache.lucene.search.TermScorer.score(TermScorer.java:65)
+       app//org.apache.lucene.search.BooleanScorer.score(BooleanScorer.java:120)
+       java.base@21/java.lang.Thread.run(Thread.java:1583)
+
+   12.0% (60ms out of 500ms) cpu usage by thread 'elasticsearch[data-1][write][T#1]'
+     6/10 snapshots sharing following 12 elements
+       app//org.elasticsearch.index.engine.InternalEngine.index(InternalEngine.java:1000)
+     unique snapshot
+       app//org.elasticsearch.other.Frame(Other.java:1)
+
+::: {data-2}{Yq7}{eph}{10.0.0.2}{10.0.0.2:9300}{dim}
+   Hot threads at 2025-01-01T10:00:00.000Z, interval=500ms, busiestThreads=3, ignoreIdleThreads=true:
+
+   45.5% (227.5ms out of 500ms) cpu usage by thread 'elasticsearch[data-2][search][T#1]'
+     10/10 snapshots sharing following 29 elements
+       app//org.apache.lucene.search.TermScorer.score(TermScorer.java:65)
+       app//org.apache.lucene.search.BooleanScorer.score(BooleanScorer.java:120)
+       java.base@21/java.lang.Thread.run(Thread.java:1583)
+`
+
+func TestParseHotThreadsGroupsSharedStacks(t *testing.T) {
+	report := parseHotThreads(hotThreadsSample, 2)
+
+	if len(report.Nodes) != 2 || len(report.Threads) != 3 {
+		t.Fatalf("report: %+v", report)
+	}
+	top := report.Threads[0]
+	if top.Node != "data-1" || top.Pool != "search" || top.Percent != 89.3 || top.Snapshots != "10/10" {
+		t.Fatalf("top thread: %+v", top)
+	}
+	if len(top.Stack) != 2 || top.Stack[0] != "org.apache.lucene.search.TermScorer.score(TermScorer.java:65)" {
+		t.Fatalf("stack: %v", top.Stack)
+	}
+	// Only the most common stack of a thread is kept
+	if write := report.Threads[2]; write.Pool != "write" || len(write.Stack) != 1 {
+		t.Fatalf("write thread: %+v", write)
+	}
+
+	if len(report.Groups) != 2 {
+		t.Fatalf("groups: %+v", report.Groups)
+	}
+	search := report.Groups[0]
+	if search.Threads != 2 || search.TotalPercent != 134.8 || len(search.Nodes) != 2 || search.Pools[0] != "search" {
+		t.Fatalf("search group: %+v", search)
+	}
+}
@@ -19,8 +19,11 @@ type IndexService interface {
 	GetAllIndexInfos(ctx context.Context) ([]models.IndexInfo, error)
 	GetLuceneStats(ctx context.Context) ([]models.LuceneStats, error)
 	GetIndexDetailInfo(ctx context.Context, indexName string) (*models.IndexDetailInfo, error)
+	GetIndexTopInfos(ctx context.Context, pattern string) ([]models.IndexTopInfo, error)
 	GetIndexMapping(ctx context.Context, indexName string) ([]models.FieldMapping, error)
+	GetIndexFields(ctx context.Context, indexName string) ([]models.FieldMapping, error)
 	GetIndexSettings(ctx context.Context, indexName string) ([]models.IndexSettingInfo, error)
+	GetIndicesSettings(ctx context.Context, pattern string) (map[string][]models.IndexSettingInfo, error)
 	MergeCalculatorInputsFromIndex(ctx context.Context, indexName string, in *calculator.Inputs) error
 	CountDocumentsByFieldQuery(ctx context.Context, indexName, field, value string, nested bool) (int64, error)
 	FieldValueCardinality(ctx context.Context, indexName, field string, nested bool) (int64, error)
@@ -99,13 +102,13 @@ func (s *indexService) GetIndexDetailInfo(ctx context.Context, indexName string)
 		return nil, fmt.Errorf("index '%s' not found", indexName)
 	}
 
-	var basicInfo models.IndexDetailInfo
-	basicInfo.Name = indexName
-	basicInfo.SearchRate = constants.DashString
-	basicInfo.IndexRate = constants.DashString
-	basicInfo.AvgQueryTime = constants.DashString
-	basicInfo.AvgIndexTime = constants.DashString
-	currentTime := time.Now()
+	basicInfo := models.IndexDetailInfo{
+		Name:         indexName,
+		SearchRate:   constants.DashString,
+		IndexRate:    constants.DashString,
+		AvgQueryTime: constants.DashString,
+		AvgIndexTime: constants.DashString,
+	}
 
 	// Get first index from response (works with both alias and real index name)
 	var indexData map[string]interface{}
@@ -117,71 +120,101 @@ func (s *indexService) GetIndexDetailInfo(ctx context.Context, indexName string)
 	}
 	if indexData != nil {
 		if total, ok := indexData["total"].(map[string]interface{}); ok {
-			var currentQueryTotal, currentQueryTime, currentIndexTotal, currentIndexTime int64
+			rates := s.sampleRates(indexName, total, time.Now())
+			basicInfo.SearchRate = util.FormatRateOrDash(rates.SearchRate)
+			basicInfo.IndexRate = util.FormatRateOrDash(rates.IndexRate)
+			basicInfo.AvgQueryTime = util.FormatMillisOrDash(rates.AvgQueryTime)
+			basicInfo.AvgIndexTime = util.FormatMillisOrDash(rates.AvgIndexTime)
+		}
+	}
 
-			if search, ok := total["search"].(map[string]interface{}); ok {
-				if queryTotal, ok := search["query_total"].(float64); ok {
-					currentQueryTotal = int64(queryTotal)
-				}
-				if queryTime, ok := search["query_time_in_millis"].(float64); ok {
-					currentQueryTime = int64(queryTime)
-				}
-			}
-			if indexing, ok := total["indexing"].(map[string]interface{}); ok {
-				if indexTotal, ok := indexing["index_total"].(float64); ok {
-					currentIndexTotal = int64(indexTotal)
-				}
-				if indexTime, ok := indexing["index_time_in_millis"].(float64); ok {
-					currentIndexTime = int64(indexTime)
-				}
-			}
-			if prevSnapshot, exists := s.cache.GetSnapshot(indexName); exists {
-				timeDelta := currentTime.Sub(prevSnapshot.Timestamp).Seconds()
-				if timeDelta > 0 {
-					queryDelta := currentQueryTotal - prevSnapshot.QueryTotal
-					if queryDelta > 0 {
-						searchRate := float64(queryDelta) / timeDelta
-						basicInfo.SearchRate = s.formatRate(searchRate)
-
-						queryTimeDelta := currentQueryTime - prevSnapshot.QueryTime
-						avgQueryTime := float64(queryTimeDelta) / float64(queryDelta)
-						basicInfo.AvgQueryTime = fmt.Sprintf(constants.TimeFormatMS, avgQueryTime)
-					} else {
-						basicInfo.SearchRate = constants.DashString
-					}
-					indexDelta := currentIndexTotal - prevSnapshot.IndexTotal
-					if indexDelta > 0 {
-						indexRate := float64(indexDelta) / timeDelta
-						basicInfo.IndexRate = s.formatRate(indexRate)
-
-						indexTimeDelta := currentIndexTime - prevSnapshot.IndexTime
-						avgIndexTime := float64(indexTimeDelta) / float64(indexDelta)
-						basicInfo.AvgIndexTime = fmt.Sprintf(constants.TimeFormatMS, avgIndexTime)
-					} else {
-						basicInfo.IndexRate = constants.DashString
-					}
-				}
-			} else {
-				if currentQueryTotal > 0 {
-					basicInfo.AvgQueryTime = fmt.Sprintf(constants.TimeFormatMS, float64(currentQueryTime)/float64(currentQueryTotal))
-				}
-				if currentIndexTotal > 0 {
-					basicInfo.AvgIndexTime = fmt.Sprintf(constants.TimeFormatMS, float64(currentIndexTime)/float64(currentIndexTotal))
-				}
-			}
-			newSnapshot := &models.IndexStatsSnapshot{
-				IndexName:  indexName,
-				QueryTotal: currentQueryTotal,
-				QueryTime:  currentQueryTime,
-				IndexTotal: currentIndexTotal,
-				IndexTime:  currentIndexTime,
-				Timestamp:  currentTime,
+	return &basicInfo, nil
+}
+
+// GetIndexTopInfos samples the rates of every index matching pattern (all indices when
+// empty) with a single stats call. Rates need two calls, the first one only seeds the cache.
+func (s *indexService) GetIndexTopInfos(ctx context.Context, pattern string) ([]models.IndexTopInfo, error) {
+	statsData, err := s.client.GetIndexStats(ctx, pattern)
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrIndexStatsRequestFailed, err)
+	}
+
+	now := time.Now()
+	indexStats := parseIndexStatsData(statsData)
+	infos := make([]models.IndexTopInfo, 0, len(indexStats))
+	for indexName, total := range indexStats {
+		infos = append(infos, models.IndexTopInfo{
+			Name:  indexName,
+			Rates: s.sampleRates(indexName, total, now),
+		})
+	}
+	return infos, nil
+}
+
+// sampleRates stores a new snapshot of the index and returns the rates since the previous one
+func (s *indexService) sampleRates(indexName string, total map[string]interface{}, now time.Time) models.IndexRates {
+	current := snapshotFromStats(indexName, total, now)
+	prev, _ := s.cache.GetSnapshot(indexName)
+	s.cache.SetSnapshot(current)
+	return computeIndexRates(prev, current)
+}
+
+func snapshotFromStats(indexName string, total map[string]interface{}, now time.Time) *models.IndexStatsSnapshot {
+	counter := func(section, field string) int64 {
+		if data, ok := total[section].(map[string]interface{}); ok {
+			if v, ok := data[field].(float64); ok {
+				return int64(v)
 			}
-			s.cache.SetSnapshot(newSnapshot)
 		}
+		return 0
+	}
+	return &models.IndexStatsSnapshot{
+		IndexName:    indexName,
+		QueryTotal:   counter("search", "query_total"),
+		QueryTime:    counter("search", "query_time_in_millis"),
+		IndexTotal:   counter("indexing", "index_total"),
+		IndexTime:    counter("indexing", "index_time_in_millis"),
+		RefreshTotal: counter("refresh", "total"),
+		RefreshTime:  counter("refresh", "total_time_in_millis"),
+		MergeTotal:   counter("merges", "total"),
+		MergeTime:    counter("merges", "total_time_in_millis"),
+		Timestamp:    now,
 	}
+}
 
-	return &basicInfo, nil
+// computeIndexRates derives the rates between two snapshots; prev may be nil
+func computeIndexRates(prev, current *models.IndexStatsSnapshot) models.IndexRates {
+	rates := models.IndexRates{SearchRate: -1, IndexRate: -1, AvgQueryTime: -1, AvgIndexTime: -1, AvgRefreshTime: -1, AvgMergeTime: -1}
+	avg := func(timeMs, ops int64) float64 {
+		if ops > 0 {
+			return float64(timeMs) / float64(ops)
+		}
+		return -1
+	}
+
+	if prev == nil {
+		rates.AvgQueryTime = avg(current.QueryTime, current.QueryTotal)
+		rates.AvgIndexTime = avg(current.IndexTime, current.IndexTotal)
+		rates.AvgRefreshTime = avg(current.RefreshTime, current.RefreshTotal)
+		rates.AvgMergeTime = avg(current.MergeTime, current.MergeTotal)
+		return rates
+	}
+
+	timeDelta := current.Timestamp.Sub(prev.Timestamp).Seconds()
+	if timeDelta <= 0 {
+		return rates
+	}
+	if queryDelta := current.QueryTotal - prev.QueryTotal; queryDelta > 0 {
+		rates.SearchRate = float64(queryDelta) / timeDelta
+		rates.AvgQueryTime = avg(current.QueryTime-prev.QueryTime, queryDelta)
+	}
+	if indexDelta := current.IndexTotal - prev.IndexTotal; indexDelta > 0 {
+		rates.IndexRate = float64(indexDelta) / timeDelta
+		rates.AvgIndexTime = avg(current.IndexTime-prev.IndexTime, indexDelta)
+	}
+	rates.AvgRefreshTime = avg(current.RefreshTime-prev.RefreshTime, current.RefreshTotal-prev.RefreshTotal)
+	rates.AvgMergeTime = avg(current.MergeTime-prev.MergeTime, current.MergeTotal-prev.MergeTotal)
+	return rates
 }
 
 func parseShardReplicaFromSettings(settings []models.IndexSettingInfo) (shards, replicas int) {
@@ -233,16 +266,6 @@ func (s *indexService) MergeCalculatorInputsFromIndex(ctx context.Context, index
 	return nil
 }
 
-func (s *indexService) formatRate(rate float64) string {
-	if rate >= constants.ThousandDivisor {
-		return fmt.Sprintf(constants.RateFormatK, rate/constants.ThousandDivisor)
-	} else if rate >= 1 {
-		return fmt.Sprintf(constants.RateFormat, rate)
-	} else {
-		return fmt.Sprintf(constants.RateFormat2, rate)
-	}
-}
-
 func parseLuceneStats(indexName string, segments, indexing map[string]interface{}) models.LuceneStats {
 	stats := models.LuceneStats{
 		IndexName: indexName,
@@ -323,6 +346,16 @@ func parseLuceneStats(indexName string, segments, indexing map[string]interface{
 }
 
 func (s *indexService) GetIndexMapping(ctx context.Context, indexName string) ([]models.FieldMapping, error) {
+	return s.getMappingFields(ctx, indexName, false)
+}
+
+// GetIndexFields is GetIndexMapping with multi-fields such as title.keyword listed after their
+// parent, for callers that address fields by path
+func (s *indexService) GetIndexFields(ctx context.Context, indexName string) ([]models.FieldMapping, error) {
+	return s.getMappingFields(ctx, indexName, true)
+}
+
+func (s *indexService) getMappingFields(ctx context.Context, indexName string, multiFields bool) ([]models.FieldMapping, error) {
 	mappingData, err := s.client.GetIndexMapping(ctx, indexName)
 	if err != nil {
 		return nil, fmt.Errorf("mapping request failed: %w", err)
@@ -335,7 +368,7 @@ func (s *indexService) GetIndexMapping(ctx context.Context, indexName string) ([
 		if indexMap, ok := indexData.(map[string]interface{}); ok {
 			if mappings, ok := indexMap["mappings"].(map[string]interface{}); ok {
 				if properties, ok := mappings["properties"].(map[string]interface{}); ok {
-					fields = extractFields(properties, "", 0)
+					fields = extractFields(properties, "", 0, multiFields)
 				}
 			}
 		}
@@ -344,7 +377,9 @@ func (s *indexService) GetIndexMapping(ctx context.Context, indexName string) ([
 	return fields, nil
 }
 
-func extractFields(properties map[string]interface{}, prefix string, depth int) []models.FieldMapping {
+// extractFields flattens mapping properties into paths; multiFields also adds the multi-fields
+// of each field
+func extractFields(properties map[string]interface{}, prefix string, depth int, multiFields bool) []models.FieldMapping {
 	var fields []models.FieldMapping
 
 	for fieldName, fieldData := range properties {
@@ -374,11 +409,16 @@ func extractFields(properties map[string]interface{}, prefix string, depth int)
 				}
 				fields = append(fields, field)
 				// Recursively extract nested fields
-				nestedFields := extractFields(nestedProps, path, depth+1)
+				nestedFields := extractFields(nestedProps, path, depth+1, multiFields)
 				fields = append(fields, nestedFields...)
 			} else {
 				fields = append(fields, field)
 			}
+
+			// Multi-fields such as title.keyword
+			if subFields, ok := fieldMap["fields"].(map[string]interface{}); ok && multiFields {
+				fields = append(fields, extractFields(subFields, path, depth+1, true)...)
+			}
 		}
 	}
 
@@ -422,6 +462,42 @@ func (s *indexService) GetIndexSettings(ctx context.Context, indexName string) (
 	return settings, nil
 }
 
+// GetIndicesSettings returns the flattened settings of every index matching pattern, keyed by
+// index name. Settings left at their default are included with the default value, so a
+// desired state may pin them too.
+func (s *indexService) GetIndicesSettings(ctx context.Context, pattern string) (map[string][]models.IndexSettingInfo, error) {
+	settingsData, err := s.client.GetIndexSettingsWithDefaults(ctx, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("settings request failed: %w", err)
+	}
+	return parseIndicesSettings(settingsData), nil
+}
+
+func parseIndicesSettings(settingsData map[string]interface{}) map[string][]models.IndexSettingInfo {
+	settings := make(map[string][]models.IndexSettingInfo, len(settingsData))
+	for name, indexData := range settingsData {
+		indexMap, ok := indexData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		settingsMap, ok := indexMap["settings"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		flat := flattenSettings(settingsMap, "")
+		if defaults, ok := indexMap["defaults"].(map[string]interface{}); ok {
+			set := settingsByKey(flat)
+			for _, d := range flattenSettings(defaults, "") {
+				if _, ok := set[d.Key]; !ok {
+					flat = append(flat, d)
+				}
+			}
+		}
+		settings[name] = flat
+	}
+	return settings
+}
+
 func flattenSettings(data map[string]interface{}, prefix string) []models.IndexSettingInfo {
 	var settings []models.IndexSettingInfo
 
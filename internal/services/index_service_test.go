@@ -0,0 +1,77 @@
+package services
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/mertbahardogan/escope/internal/models"
+)
+
+func TestComputeIndexRates(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	prev := &models.IndexStatsSnapshot{QueryTotal: 100, QueryTime: 500, IndexTotal: 10, IndexTime: 20,
+		RefreshTotal: 4, RefreshTime: 40, MergeTotal: 1, MergeTime: 300, Timestamp: start}
+	current := &models.IndexStatsSnapshot{QueryTotal: 300, QueryTime: 1500, IndexTotal: 10, IndexTime: 20,
+		RefreshTotal: 6, RefreshTime: 70, MergeTotal: 1, MergeTime: 300, Timestamp: start.Add(2 * time.Second)}
+
+	rates := computeIndexRates(prev, current)
+	if rates.SearchRate != 100 || rates.AvgQueryTime != 5 {
+		t.Fatalf("search: %+v", rates)
+	}
+	if rates.IndexRate != -1 || rates.AvgIndexTime != -1 || rates.AvgMergeTime != -1 {
+		t.Fatalf("idle operations should be -1: %+v", rates)
+	}
+	if rates.AvgRefreshTime != 15 {
+		t.Fatalf("refresh: %+v", rates)
+	}
+
+	// Without a previous snapshot only lifetime averages are known
+	first := computeIndexRates(nil, current)
+	if first.SearchRate != -1 || first.AvgQueryTime != 5 || first.AvgMergeTime != 300 {
+		t.Fatalf("first sample: %+v", first)
+	}
+}
+
+func TestSnapshotFromStats(t *testing.T) {
+	total := map[string]interface{}{
+		"search":   map[string]interface{}{"query_total": float64(7), "query_time_in_millis": float64(70)},
+		"refresh":  map[string]interface{}{"total": float64(3), "total_time_in_millis": float64(9)},
+		"merges":   map[string]interface{}{"total": float64(2)},
+		"indexing": "unexpected",
+	}
+	s := snapshotFromStats("logs", total, time.Time{})
+	if s.IndexName != "logs" || s.QueryTotal != 7 || s.QueryTime != 70 || s.RefreshTotal != 3 ||
+		s.RefreshTime != 9 || s.MergeTotal != 2 || s.MergeTime != 0 || s.IndexTotal != 0 {
+		t.Fatalf("snapshot: %+v", s)
+	}
+}
+
+func TestExtractFieldsMultiFields(t *testing.T) {
+	var properties map[string]interface{}
+	raw := `{"title": {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+	  "user": {"properties": {"name": {"type": "text", "fields": {"raw": {"type": "keyword"}}}}}}`
+	if err := json.Unmarshal([]byte(raw), &properties); err != nil {
+		t.Fatal(err)
+	}
+
+	paths := func(fields []models.FieldMapping) []string {
+		out := make([]string, 0, len(fields))
+		for _, f := range fields {
+			out = append(out, f.Path)
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	// escope index mapping, analyzer and the dashboard list the mapped fields only
+	if got := paths(extractFields(properties, "", 0, false)); !reflect.DeepEqual(got, []string{"title", "user", "user.name"}) {
+		t.Fatalf("without multi-fields: %v", got)
+	}
+	got := paths(extractFields(properties, "", 0, true))
+	if !reflect.DeepEqual(got, []string{"title", "title.keyword", "user", "user.name", "user.name.raw"}) {
+		t.Fatalf("with multi-fields: %v", got)
+	}
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/mertbahardogan/escope/internal/models"
+)
+
+// DiffFieldMappings compares two flattened mappings field by field. Fields only in right are
+// added, fields only in left are removed; fields in both report type, analyzer and
+// index: true/false changes. Changes are sorted by path.
+func DiffFieldMappings(left, right []models.FieldMapping) []models.MappingChange {
+	leftByPath := make(map[string]models.FieldMapping, len(left))
+	for _, f := range left {
+		leftByPath[f.Path] = f
+	}
+	rightByPath := make(map[string]models.FieldMapping, len(right))
+	for _, f := range right {
+		rightByPath[f.Path] = f
+	}
+
+	changes := make([]models.MappingChange, 0)
+	for path, l := range leftByPath {
+		r, ok := rightByPath[path]
+		if !ok {
+			changes = append(changes, models.MappingChange{Path: path, Change: models.MappingFieldRemoved, Left: l.Type, Right: "-"})
+			continue
+		}
+		if l.Type != r.Type {
+			changes = append(changes, models.MappingChange{Path: path, Change: models.MappingTypeChanged, Left: l.Type, Right: r.Type})
+		}
+		for _, a := range []struct{ name, left, right string }{
+			{"analyzer", l.Analyzer, r.Analyzer},
+			{"search_analyzer", l.SearchAnalyzer, r.SearchAnalyzer},
+			{"normalizer", l.Normalizer, r.Normalizer},
+		} {
+			if a.left != a.right {
+				changes = append(changes, models.MappingChange{Path: path, Change: models.MappingAnalyzerChanged,
+					Attribute: a.name, Left: a.left, Right: a.right})
+			}
+		}
+		if l.Index != r.Index {
+			changes = append(changes, models.MappingChange{Path: path, Change: models.MappingIndexToggled, Left: l.Index, Right: r.Index})
+		}
+	}
+	for path, r := range rightByPath {
+		if _, ok := leftByPath[path]; !ok {
+			changes = append(changes, models.MappingChange{Path: path, Change: models.MappingFieldAdded, Left: "-", Right: r.Type})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Path != changes[j].Path {
+			return changes[i].Path < changes[j].Path
+		}
+		return changes[i].Change < changes[j].Change
+	})
+	return changes
+}
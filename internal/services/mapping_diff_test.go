@@ -0,0 +1,51 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mertbahardogan/escope/internal/models"
+)
+
+func mappingFields(t *testing.T, raw string) []models.FieldMapping {
+	t.Helper()
+	var properties map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &properties); err != nil {
+		t.Fatal(err)
+	}
+	return extractFields(properties, "", 0, true)
+}
+
+func TestDiffFieldMappings(t *testing.T) {
+	left := mappingFields(t, `{
+	  "title":  {"type": "text", "analyzer": "standard", "fields": {"keyword": {"type": "keyword"}}},
+	  "price":  {"type": "float"},
+	  "notes":  {"type": "text"},
+	  "user":   {"properties": {"name": {"type": "keyword"}}}}`)
+	right := mappingFields(t, `{
+	  "title":  {"type": "text", "analyzer": "english"},
+	  "price":  {"type": "scaled_float"},
+	  "notes":  {"type": "text", "index": false},
+	  "user":   {"properties": {"name": {"type": "keyword"}, "email": {"type": "keyword"}}}}`)
+
+	changes := DiffFieldMappings(left, right)
+	want := []models.MappingChange{
+		{Path: "notes", Change: models.MappingIndexToggled, Left: "true", Right: "false"},
+		{Path: "price", Change: models.MappingTypeChanged, Left: "float", Right: "scaled_float"},
+		{Path: "title", Change: models.MappingAnalyzerChanged, Attribute: "analyzer", Left: "standard", Right: "english"},
+		{Path: "title.keyword", Change: models.MappingFieldRemoved, Left: "keyword", Right: "-"},
+		{Path: "user.email", Change: models.MappingFieldAdded, Left: "-", Right: "keyword"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("changes: %+v", changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Fatalf("change %d: got %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	if same := DiffFieldMappings(left, left); len(same) != 0 {
+		t.Fatalf("identical mappings: %+v", same)
+	}
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/interfaces"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+// breakerOrder lists the common breakers first; others (model_inference, eql_sequence, ...)
+// follow alphabetically
+var breakerOrder = map[string]int{
+	constants.ParentBreaker: 0,
+	"fielddata":             1,
+	"request":               2,
+	"in_flight_requests":    3,
+	"accounting":            4,
+}
+
+type MemoryService interface {
+	GetNodeMemoryInfo(ctx context.Context) ([]models.NodeMemoryInfo, error)
+	GetBreakerWarnings(ctx context.Context) (*models.BreakerWarnings, error)
+}
+
+type memoryService struct {
+	client interfaces.ElasticClient
+}
+
+func NewMemoryService(client interfaces.ElasticClient) MemoryService {
+	return &memoryService{
+		client: client,
+	}
+}
+
+// GetNodeMemoryInfo reads the breakers and the fielddata, query and request caches of every
+// node, sorted by node name
+func (s *memoryService) GetNodeMemoryInfo(ctx context.Context) ([]models.NodeMemoryInfo, error) {
+	statsData, err := s.client.GetNodesStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrFailedToGetNodeStats, err)
+	}
+	return parseNodeMemoryInfos(statsData), nil
+}
+
+func parseNodeMemoryInfos(statsData map[string]interface{}) []models.NodeMemoryInfo {
+	infos := make([]models.NodeMemoryInfo, 0)
+	nodes, _ := statsData[constants.NodesField].(map[string]interface{})
+	for nodeID, nodeData := range nodes {
+		node, ok := nodeData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		info := models.NodeMemoryInfo{Node: util.GetStringField(node, constants.NameField)}
+		if info.Node == "" {
+			info.Node = nodeID
+		}
+
+		if jvm, ok := node[constants.JVMField].(map[string]interface{}); ok {
+			if mem, ok := jvm[constants.MemField].(map[string]interface{}); ok {
+				info.HeapUsedBytes = int64Field(mem, constants.HeapUsedInBytesField)
+				info.HeapMaxBytes = int64Field(mem, constants.HeapMaxInBytesField)
+			}
+		}
+
+		breakers, _ := node[constants.BreakersField].(map[string]interface{})
+		for name, data := range breakers {
+			breaker, ok := data.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			stats := models.BreakerStats{
+				Name:           name,
+				LimitBytes:     int64Field(breaker, constants.LimitSizeInBytesField),
+				EstimatedBytes: int64Field(breaker, constants.EstimatedSizeInBytesField),
+				Tripped:        int64Field(breaker, constants.TrippedField),
+			}
+			stats.Overhead, _ = breaker[constants.OverheadField].(float64)
+			if stats.LimitBytes > 0 {
+				stats.UsedPercent = float64(stats.EstimatedBytes) / float64(stats.LimitBytes) * constants.HundredMultiplier
+			}
+			info.Breakers = append(info.Breakers, stats)
+		}
+		sort.Slice(info.Breakers, func(i, j int) bool {
+			oi, iKnown := breakerOrder[info.Breakers[i].Name]
+			oj, jKnown := breakerOrder[info.Breakers[j].Name]
+			if iKnown != jKnown {
+				return iKnown
+			}
+			if iKnown {
+				return oi < oj
+			}
+			return info.Breakers[i].Name < info.Breakers[j].Name
+		})
+
+		indices, _ := node[constants.IndicesField].(map[string]interface{})
+		for _, name := range []string{constants.FielddataField, constants.QueryCacheField, constants.RequestCacheField} {
+			cache, ok := indices[name].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			stats := models.CacheStats{
+				Name:        name,
+				MemoryBytes: int64Field(cache, constants.MemorySizeInBytesField),
+				HitCount:    int64Field(cache, constants.HitCountField),
+				MissCount:   int64Field(cache, constants.MissCountField),
+				Evictions:   int64Field(cache, constants.EvictionsField),
+				HitRatio:    -1,
+			}
+			if lookups := stats.HitCount + stats.MissCount; lookups > 0 {
+				stats.HitRatio = float64(stats.HitCount) / float64(lookups) * constants.HundredMultiplier
+			}
+			info.Caches = append(info.Caches, stats)
+		}
+
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Node < infos[j].Node
+	})
+	return infos
+}
+
+// GetBreakerWarnings flags nodes whose parent breaker estimate is close to its limit. Past
+// the limit every new request on the node fails with a circuit_breaking_exception.
+func (s *memoryService) GetBreakerWarnings(ctx context.Context) (*models.BreakerWarnings, error) {
+	infos, err := s.GetNodeMemoryInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return breakerWarnings(infos), nil
+}
+
+func breakerWarnings(infos []models.NodeMemoryInfo) *models.BreakerWarnings {
+	warnings := &models.BreakerWarnings{
+		CriticalIssues: make([]string, 0),
+		WarningIssues:  make([]string, 0),
+	}
+	for _, info := range infos {
+		parent, ok := info.Breaker(constants.ParentBreaker)
+		if !ok || parent.LimitBytes <= 0 {
+			continue
+		}
+		issue := fmt.Sprintf(constants.MsgParentBreakerPressure, info.Node, parent.UsedPercent, util.FormatBytes(parent.LimitBytes))
+		switch {
+		case parent.UsedPercent >= constants.ParentBreakerCriticalPercent:
+			warnings.CriticalIssues = append(warnings.CriticalIssues, issue)
+		case parent.UsedPercent >= constants.ParentBreakerWarningPercent:
+			warnings.WarningIssues = append(warnings.WarningIssues, issue)
+		}
+	}
+	return warnings
+}
+
+func int64Field(data map[string]interface{}, field string) int64 {
+	if v, ok := data[field].(float64); ok {
+		return int64(v)
+	}
+	return 0
+}
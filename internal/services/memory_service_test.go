@@ -0,0 +1,52 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseNodeMemoryInfosAndBreakerWarnings(t *testing.T) {
+	raw := `{"nodes": {
+	  "n2": {"name": "data-2", "breakers": {
+	    "parent":    {"limit_size_in_bytes": 1000, "estimated_size_in_bytes": 970, "overhead": 1.0, "tripped": 3}}},
+	  "n1": {"name": "data-1",
+	    "jvm": {"mem": {"heap_used_in_bytes": 600, "heap_max_in_bytes": 1024}},
+	    "breakers": {
+	      "request":         {"limit_size_in_bytes": 600, "estimated_size_in_bytes": 0, "overhead": 1.0, "tripped": 0},
+	      "model_inference": {"limit_size_in_bytes": 500, "estimated_size_in_bytes": 0, "overhead": 1.0, "tripped": 0},
+	      "fielddata":       {"limit_size_in_bytes": 400, "estimated_size_in_bytes": 100, "overhead": 1.03, "tripped": 1},
+	      "parent":          {"limit_size_in_bytes": 1000, "estimated_size_in_bytes": 880, "overhead": 1.0, "tripped": 0}},
+	    "indices": {
+	      "fielddata":     {"memory_size_in_bytes": 100, "evictions": 2},
+	      "query_cache":   {"memory_size_in_bytes": 50, "hit_count": 30, "miss_count": 10, "evictions": 5},
+	      "request_cache": {"memory_size_in_bytes": 0, "hit_count": 0, "miss_count": 0, "evictions": 0}}}}}`
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		t.Fatal(err)
+	}
+
+	infos := parseNodeMemoryInfos(data)
+	if len(infos) != 2 || infos[0].Node != "data-1" || infos[0].HeapMaxBytes != 1024 {
+		t.Fatalf("infos: %+v", infos)
+	}
+	names := ""
+	for _, b := range infos[0].Breakers {
+		names += b.Name + " "
+	}
+	if names != "parent fielddata request model_inference " {
+		t.Fatalf("breaker order: %s", names)
+	}
+	if fd := infos[0].Breakers[1]; fd.UsedPercent != 25 || fd.Tripped != 1 {
+		t.Fatalf("fielddata breaker: %+v", fd)
+	}
+
+	caches := infos[0].Caches
+	if len(caches) != 3 || caches[0].HitRatio != -1 || caches[1].HitRatio != 75 || caches[2].HitRatio != -1 {
+		t.Fatalf("caches: %+v", caches)
+	}
+
+	warnings := breakerWarnings(infos)
+	if len(warnings.WarningIssues) != 1 || len(warnings.CriticalIssues) != 1 {
+		t.Fatalf("warnings: %+v", warnings)
+	}
+}
@@ -2,69 +2,113 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/mertbahardogan/escope/internal/constants"
 	"github.com/mertbahardogan/escope/internal/interfaces"
 	"github.com/mertbahardogan/escope/internal/models"
 	"github.com/mertbahardogan/escope/internal/util"
+	"io"
+	"math"
+	"os"
+	"sort"
 	"time"
 )
 
 type MonitoringService struct {
-	client interfaces.ElasticClient
+	client   interfaces.ElasticClient
+	out      io.Writer
+	recorder io.Writer
 }
 
-type MonitoringResult struct {
-	Duration           time.Duration
-	SampleCount        int
-	ClusterHealthTrend []models.ClusterInfo
-	NodeHealthTrend    []models.CheckNodeHealth
-	ShardHealthTrend   []models.ShardHealth
-	IndexHealthTrend   []models.IndexHealth
-	ResourceTrend      []models.ResourceUsage
-	PerformanceTrend   []models.Performance
-	Issues             []MonitoringIssue
-	Recommendations    []string
+func NewMonitoringService(client interfaces.ElasticClient) *MonitoringService {
+	return &MonitoringService{
+		client: client,
+		out:    os.Stdout,
+	}
 }
 
-type MonitoringIssue struct {
-	Type        string
-	Severity    string
-	Description string
-	Occurrences int
-	FirstSeen   time.Time
-	LastSeen    time.Time
+// WithProgressOutput redirects the per-sample progress lines, e.g. to stderr when the report is JSON
+func (s *MonitoringService) WithProgressOutput(w io.Writer) *MonitoringService {
+	s.out = w
+	return s
 }
 
-func NewMonitoringService(client interfaces.ElasticClient) *MonitoringService {
-	return &MonitoringService{
-		client: client,
+// WithRecorder writes every collected sample to w as one JSON line, for later Replay
+func (s *MonitoringService) WithRecorder(w io.Writer) *MonitoringService {
+	s.recorder = w
+	return s
+}
+
+// Replay rebuilds a monitoring result from recorded NDJSON samples and analyzes it offline
+func (s *MonitoringService) Replay(r io.Reader) (*models.MonitoringResult, error) {
+	result := &models.MonitoringResult{}
+
+	dec := json.NewDecoder(r)
+	for {
+		var sample models.MonitoringSample
+		if err := dec.Decode(&sample); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf(constants.ErrSampleDecodeFailed, result.SampleCount+1, err)
+		}
+		addSample(result, &sample)
+	}
+
+	if result.SampleCount > 0 {
+		result.StartedAt = result.ClusterHealthTrend[0].Timestamp
+		result.EndedAt = result.ClusterHealthTrend[len(result.ClusterHealthTrend)-1].Timestamp
+		result.Duration = result.EndedAt.Sub(result.StartedAt)
+		s.analyzeTrends(result)
+	}
+
+	return result, nil
+}
+
+func addSample(result *models.MonitoringResult, sample *models.MonitoringSample) {
+	result.ClusterHealthTrend = append(result.ClusterHealthTrend, sample.ClusterHealth)
+	result.NodeHealthTrend = append(result.NodeHealthTrend, sample.NodeHealths...)
+	result.ShardHealthTrend = append(result.ShardHealthTrend, sample.ShardHealth)
+	result.IndexHealthTrend = append(result.IndexHealthTrend, sample.IndexHealths...)
+	if sample.ResourceUsage != nil {
+		result.ResourceTrend = append(result.ResourceTrend, *sample.ResourceUsage)
+	}
+	if sample.Performance != nil {
+		result.PerformanceTrend = append(result.PerformanceTrend, *sample.Performance)
+	}
+	if sample.ThreadPools != nil {
+		result.ThreadPoolTrend = append(result.ThreadPoolTrend, *sample.ThreadPools)
 	}
+	result.SampleCount++
 }
 
 func (s *MonitoringService) MonitorCluster(ctx context.Context, duration time.Duration,
-	interval time.Duration) (*MonitoringResult, error) {
+	interval time.Duration) (*models.MonitoringResult, error) {
 	if interval > duration {
 		interval = duration / constants.DefaultInterval
 		if interval < time.Duration(constants.MinInterval)*time.Second {
 			interval = time.Duration(constants.MinInterval) * time.Second
 		}
-		fmt.Printf("Warning: Interval adjusted to %v (duration was too short)\n", interval)
+		fmt.Fprintf(s.out, "Warning: Interval adjusted to %v (duration was too short)\n", interval)
 	}
 
-	result := &MonitoringResult{
+	result := &models.MonitoringResult{
 		Duration:    duration,
+		StartedAt:   time.Now(),
 		SampleCount: 0,
 	}
 
 	checkService := NewCheckService(s.client)
+	threadPoolService := NewThreadPoolService(s.client)
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	endTime := time.Now().Add(duration)
 
-	fmt.Printf("Starting cluster monitoring for %v (sampling every %v)\n", duration, interval)
-	fmt.Printf("Monitoring will complete at %s\n\n", endTime.Format("15:04:05"))
+	fmt.Fprintf(s.out, "Starting cluster monitoring for %v (sampling every %v)\n", duration, interval)
+	fmt.Fprintf(s.out, "Monitoring will complete at %s\n\n", endTime.Format("15:04:05"))
 
 	for {
 		select {
@@ -77,196 +121,212 @@ func (s *MonitoringService) MonitorCluster(ctx context.Context, duration time.Du
 
 			clusterHealth, err := checkService.GetClusterHealthCheck(ctx)
 			if err != nil {
-				fmt.Printf("Warning: Failed to get cluster health at %s: %v\n", time.Now().Format("15:04:05"), err)
+				fmt.Fprintf(s.out, "Warning: Failed to get cluster health at %s: %v\n", time.Now().Format("15:04:05"), err)
 				continue
 			}
 
 			nodeHealths, err := checkService.GetNodeHealthCheck(ctx)
 			if err != nil {
-				fmt.Printf("Warning: Failed to get node health at %s: %v\n", time.Now().Format("15:04:05"), err)
+				fmt.Fprintf(s.out, "Warning: Failed to get node health at %s: %v\n", time.Now().Format("15:04:05"), err)
 				continue
 			}
 
 			shardHealth, err := checkService.GetShardHealthCheck(ctx)
 			if err != nil {
-				fmt.Printf("Warning: Failed to get shard health at %s: %v\n", time.Now().Format("15:04:05"), err)
+				fmt.Fprintf(s.out, "Warning: Failed to get shard health at %s: %v\n", time.Now().Format("15:04:05"), err)
 				continue
 			}
 
 			indexHealths, err := checkService.GetIndexHealthCheck(ctx)
 			if err != nil {
-				fmt.Printf("Warning: Failed to get index health at %s: %v\n", time.Now().Format("15:04:05"), err)
+				fmt.Fprintf(s.out, "Warning: Failed to get index health at %s: %v\n", time.Now().Format("15:04:05"), err)
 				continue
 			}
 
 			resourceUsage, err := checkService.GetResourceUsageCheck(ctx)
 			if err != nil {
-				fmt.Printf("Warning: Failed to get resource usage at %s: %v\n", time.Now().Format("15:04:05"), err)
+				fmt.Fprintf(s.out, "Warning: Failed to get resource usage at %s: %v\n", time.Now().Format("15:04:05"), err)
 				continue
 			}
 
 			performance, err := checkService.GetPerformanceCheck(ctx)
 			if err != nil {
-				fmt.Printf("Warning: Failed to get performance stats at %s: %v\n", time.Now().Format("15:04:05"), err)
+				fmt.Fprintf(s.out, "Warning: Failed to get performance stats at %s: %v\n", time.Now().Format("15:04:05"), err)
 				continue
 			}
 
-			result.ClusterHealthTrend = append(result.ClusterHealthTrend, *clusterHealth)
-			result.NodeHealthTrend = append(result.NodeHealthTrend, nodeHealths...)
-			result.ShardHealthTrend = append(result.ShardHealthTrend, *shardHealth)
-			result.IndexHealthTrend = append(result.IndexHealthTrend, indexHealths...)
-			if resourceUsage != nil {
-				result.ResourceTrend = append(result.ResourceTrend, *resourceUsage)
+			threadPools, err := threadPoolService.GetThreadPoolSnapshot(ctx)
+			if err != nil {
+				fmt.Fprintf(s.out, "Warning: Failed to get thread pool stats at %s: %v\n", time.Now().Format("15:04:05"), err)
+				continue
 			}
-			if performance != nil {
-				result.PerformanceTrend = append(result.PerformanceTrend, *performance)
+
+			sample := &models.MonitoringSample{
+				ClusterHealth: *clusterHealth,
+				NodeHealths:   nodeHealths,
+				ShardHealth:   *shardHealth,
+				IndexHealths:  indexHealths,
+				ResourceUsage: resourceUsage,
+				Performance:   performance,
+				ThreadPools:   threadPools,
 			}
+			addSample(result, sample)
 
-			result.SampleCount++
+			if s.recorder != nil {
+				if err := json.NewEncoder(s.recorder).Encode(sample); err != nil {
+					fmt.Fprintf(s.out, "Warning: Failed to record sample %d: %v\n", result.SampleCount, err)
+				}
+			}
 
-			fmt.Printf("Sample %d collected at %s\n",
+			fmt.Fprintf(s.out, "Sample %d collected at %s\n",
 				result.SampleCount, time.Now().Format("15:04:05"))
 
 			if clusterHealth.Status == constants.HealthRed {
-				fmt.Printf("!!! CRITICAL: Cluster status is RED at %s\n", time.Now().Format("15:04:05"))
+				fmt.Fprintf(s.out, "!!! CRITICAL: Cluster status is RED at %s\n", time.Now().Format("15:04:05"))
 			}
 			if clusterHealth.UnassignedShards > 0 {
-				fmt.Printf("!!! WARNING: %d unassigned shards detected at %s\n",
+				fmt.Fprintf(s.out, "!!! WARNING: %d unassigned shards detected at %s\n",
 					clusterHealth.UnassignedShards, time.Now().Format("15:04:05"))
 			}
 		}
 	}
 
 monitoringComplete:
+	result.EndedAt = time.Now()
 
-	fmt.Printf("\nMonitoring completed. Collected %d samples over %v\n", result.SampleCount, duration)
+	fmt.Fprintf(s.out, "\nMonitoring completed. Collected %d samples over %v\n", result.SampleCount, duration)
 
 	s.analyzeTrends(result)
 
 	return result, nil
 }
 
-func (s *MonitoringService) analyzeTrends(result *MonitoringResult) {
+func (s *MonitoringService) analyzeTrends(result *models.MonitoringResult) {
 	s.analyzeClusterHealthTrend(result)
 
 	s.analyzeResourceTrends(result)
 
 	s.analyzePerformanceTrends(result)
 
+	s.analyzeThreadPoolTrends(result)
+
 	s.generateRecommendations(result)
+
+	result.Stats = computeTrendStats(result)
 }
 
-func (s *MonitoringService) analyzeClusterHealthTrend(result *MonitoringResult) {
+func (s *MonitoringService) analyzeClusterHealthTrend(result *models.MonitoringResult) {
 	if len(result.ClusterHealthTrend) == 0 {
 		return
 	}
 
-	redCount := 0
-	yellowCount := 0
-	greenCount := 0
+	var redSeen, yellowSeen, unassignedSeen []time.Time
 	unassignedShardsTotal := 0
 
 	for _, health := range result.ClusterHealthTrend {
 		switch health.Status {
 		case constants.HealthRed:
-			redCount++
+			redSeen = append(redSeen, health.Timestamp)
 		case constants.HealthYellow:
-			yellowCount++
-		case constants.HealthGreen:
-			greenCount++
+			yellowSeen = append(yellowSeen, health.Timestamp)
+		}
+		if health.UnassignedShards > 0 {
+			unassignedSeen = append(unassignedSeen, health.Timestamp)
 		}
 		unassignedShardsTotal += health.UnassignedShards
 	}
 
-	if redCount > 0 {
-		result.Issues = append(result.Issues, MonitoringIssue{
-			Type:        "Cluster Status",
-			Severity:    constants.SeverityCritical,
-			Description: fmt.Sprintf("Cluster was RED in %d/%d samples", redCount, result.SampleCount),
-			Occurrences: redCount,
-			FirstSeen:   result.ClusterHealthTrend[0].Timestamp,
-			LastSeen:    result.ClusterHealthTrend[len(result.ClusterHealthTrend)-1].Timestamp,
-		})
+	if len(redSeen) > 0 {
+		result.Issues = append(result.Issues, newMonitoringIssue("Cluster Status", constants.SeverityCritical,
+			fmt.Sprintf("Cluster was RED in %d/%d samples", len(redSeen), result.SampleCount),
+			len(redSeen), redSeen))
 	}
 
-	if yellowCount > result.SampleCount/2 {
-		result.Issues = append(result.Issues, MonitoringIssue{
-			Type:        "Cluster Status",
-			Severity:    constants.SeverityWarning,
-			Description: fmt.Sprintf("Cluster was YELLOW in %d/%d samples (>50%%)", yellowCount, result.SampleCount),
-			Occurrences: yellowCount,
-			FirstSeen:   result.ClusterHealthTrend[0].Timestamp,
-			LastSeen:    result.ClusterHealthTrend[len(result.ClusterHealthTrend)-1].Timestamp,
-		})
+	if len(yellowSeen) > result.SampleCount/2 {
+		result.Issues = append(result.Issues, newMonitoringIssue("Cluster Status", constants.SeverityWarning,
+			fmt.Sprintf("Cluster was YELLOW in %d/%d samples (>50%%)", len(yellowSeen), result.SampleCount),
+			len(yellowSeen), yellowSeen))
 	}
 
 	if unassignedShardsTotal > 0 {
-		result.Issues = append(result.Issues, MonitoringIssue{
-			Type:        "Shard Assignment",
-			Severity:    constants.SeverityWarning,
-			Description: fmt.Sprintf("Total unassigned shards across samples: %d", unassignedShardsTotal),
-			Occurrences: unassignedShardsTotal,
-			FirstSeen:   result.ClusterHealthTrend[0].Timestamp,
-			LastSeen:    result.ClusterHealthTrend[len(result.ClusterHealthTrend)-1].Timestamp,
-		})
+		result.Issues = append(result.Issues, newMonitoringIssue("Shard Assignment", constants.SeverityWarning,
+			fmt.Sprintf("Total unassigned shards across samples: %d", unassignedShardsTotal),
+			unassignedShardsTotal, unassignedSeen))
 	}
 }
 
-func (s *MonitoringService) analyzeResourceTrends(result *MonitoringResult) {
+func (s *MonitoringService) analyzeResourceTrends(result *models.MonitoringResult) {
 	if len(result.ResourceTrend) == 0 {
 		return
 	}
 
-	highHeapSamples := 0
+	var highHeapSeen []time.Time
 	maxHeapUsage := 0.0
 	for _, resource := range result.ResourceTrend {
 		if resource.HeapUsage > 80 {
-			highHeapSamples++
+			highHeapSeen = append(highHeapSeen, resource.Timestamp)
 		}
 		if resource.HeapUsage > maxHeapUsage {
 			maxHeapUsage = resource.HeapUsage
 		}
 	}
 
-	if highHeapSamples > result.SampleCount/3 {
-		result.Issues = append(result.Issues, MonitoringIssue{
-			Type:     "Resource Usage",
-			Severity: constants.SeverityWarning,
-			Description: fmt.Sprintf("High heap usage (>80%%) in %d/%d samples, max: %.1f%%",
-				highHeapSamples, result.SampleCount, maxHeapUsage),
-			Occurrences: highHeapSamples,
-			FirstSeen:   result.ResourceTrend[0].Timestamp,
-			LastSeen:    result.ResourceTrend[len(result.ResourceTrend)-1].Timestamp,
-		})
+	if len(highHeapSeen) > result.SampleCount/3 {
+		result.Issues = append(result.Issues, newMonitoringIssue("Resource Usage", constants.SeverityWarning,
+			fmt.Sprintf("High heap usage (>80%%) in %d/%d samples, max: %.1f%%",
+				len(highHeapSeen), result.SampleCount, maxHeapUsage),
+			len(highHeapSeen), highHeapSeen))
 	}
 
-	highDiskSamples := 0
+	// Samples carrying per-node disks are measured against the cluster's watermarks;
+	// older recordings only have the aggregate usage
+	var highDiskSeen, lowWatermarkSeen, floodStageSeen []time.Time
 	for _, resource := range result.ResourceTrend {
-		diskUsed := resource.DiskTotal - resource.DiskAvailable
-		diskPercent := util.CalculatePercentage(diskUsed, resource.DiskTotal)
-		if diskPercent > 85 {
-			highDiskSamples++
+		if len(resource.NodeDisks) == 0 {
+			diskUsed := resource.DiskTotal - resource.DiskAvailable
+			diskPercent := util.CalculatePercentage(diskUsed, resource.DiskTotal)
+			if diskPercent > 85 {
+				highDiskSeen = append(highDiskSeen, resource.Timestamp)
+			}
+			continue
+		}
+
+		nearFlood, pastLow := false, false
+		for _, disk := range resource.NodeDisks {
+			nearFlood = nearFlood || disk.Status.NearFloodStage
+			pastLow = pastLow || disk.Status.Level != models.DiskLevelOK
+		}
+		if nearFlood {
+			floodStageSeen = append(floodStageSeen, resource.Timestamp)
+		} else if pastLow {
+			lowWatermarkSeen = append(lowWatermarkSeen, resource.Timestamp)
 		}
 	}
 
-	if highDiskSamples > 0 {
-		result.Issues = append(result.Issues, MonitoringIssue{
-			Type:     "Resource Usage",
-			Severity: constants.SeverityWarning,
-			Description: fmt.Sprintf("High disk usage (>85%%) in %d/%d samples",
-				highDiskSamples, result.SampleCount),
-			Occurrences: highDiskSamples,
-			FirstSeen:   result.ResourceTrend[0].Timestamp,
-			LastSeen:    result.ResourceTrend[len(result.ResourceTrend)-1].Timestamp,
-		})
+	if len(highDiskSeen) > 0 {
+		result.Issues = append(result.Issues, newMonitoringIssue("Resource Usage", constants.SeverityWarning,
+			fmt.Sprintf("High disk usage (>85%%) in %d/%d samples", len(highDiskSeen), result.SampleCount),
+			len(highDiskSeen), highDiskSeen))
+	}
+
+	if len(floodStageSeen) > 0 {
+		result.Issues = append(result.Issues, newMonitoringIssue("Disk Watermark", constants.SeverityCritical,
+			fmt.Sprintf("Data nodes at or near the flood stage watermark in %d/%d samples", len(floodStageSeen), result.SampleCount),
+			len(floodStageSeen), floodStageSeen))
+	}
+
+	if len(lowWatermarkSeen) > 0 {
+		result.Issues = append(result.Issues, newMonitoringIssue("Disk Watermark", constants.SeverityWarning,
+			fmt.Sprintf("Data nodes past the low disk watermark in %d/%d samples", len(lowWatermarkSeen), result.SampleCount),
+			len(lowWatermarkSeen), lowWatermarkSeen))
 	}
 }
 
-func (s *MonitoringService) analyzePerformanceTrends(result *MonitoringResult) {
+func (s *MonitoringService) analyzePerformanceTrends(result *models.MonitoringResult) {
 	if len(result.PerformanceTrend) == 0 {
 		return
 	}
-	slowIndexSamples := 0
+	var slowIndexSeen []time.Time
 	totalIndexTime := int64(0)
 	totalIndexCount := int64(0)
 
@@ -274,27 +334,22 @@ func (s *MonitoringService) analyzePerformanceTrends(result *MonitoringResult) {
 		if perf.IndexTotal > 0 {
 			avgIndexTime := float64(perf.IndexTimeInMillis) / float64(perf.IndexTotal)
 			if avgIndexTime > 100 {
-				slowIndexSamples++
+				slowIndexSeen = append(slowIndexSeen, perf.Timestamp)
 			}
 			totalIndexTime += perf.IndexTimeInMillis
 			totalIndexCount += perf.IndexTotal
 		}
 	}
 
-	if slowIndexSamples > 0 && totalIndexCount > 0 {
+	if len(slowIndexSeen) > 0 && totalIndexCount > 0 {
 		overallAvgIndexTime := float64(totalIndexTime) / float64(totalIndexCount)
-		result.Issues = append(result.Issues, MonitoringIssue{
-			Type:     "Performance",
-			Severity: constants.SeverityWarning,
-			Description: fmt.Sprintf("Slow indexing (>100ms avg) in %d/%d samples, overall avg: %.1fms",
-				slowIndexSamples, result.SampleCount, overallAvgIndexTime),
-			Occurrences: slowIndexSamples,
-			FirstSeen:   result.PerformanceTrend[0].Timestamp,
-			LastSeen:    result.PerformanceTrend[len(result.PerformanceTrend)-1].Timestamp,
-		})
-	}
-
-	slowSearchSamples := 0
+		result.Issues = append(result.Issues, newMonitoringIssue("Performance", constants.SeverityWarning,
+			fmt.Sprintf("Slow indexing (>100ms avg) in %d/%d samples, overall avg: %.1fms",
+				len(slowIndexSeen), result.SampleCount, overallAvgIndexTime),
+			len(slowIndexSeen), slowIndexSeen))
+	}
+
+	var slowSearchSeen []time.Time
 	totalSearchTime := int64(0)
 	totalSearchCount := int64(0)
 
@@ -302,28 +357,145 @@ func (s *MonitoringService) analyzePerformanceTrends(result *MonitoringResult) {
 		if perf.QueryTotal > 0 {
 			avgSearchTime := float64(perf.QueryTimeInMillis) / float64(perf.QueryTotal)
 			if avgSearchTime > 50 {
-				slowSearchSamples++
+				slowSearchSeen = append(slowSearchSeen, perf.Timestamp)
 			}
 			totalSearchTime += perf.QueryTimeInMillis
 			totalSearchCount += perf.QueryTotal
 		}
 	}
 
-	if slowSearchSamples > 0 && totalSearchCount > 0 {
+	if len(slowSearchSeen) > 0 && totalSearchCount > 0 {
 		overallAvgSearchTime := float64(totalSearchTime) / float64(totalSearchCount)
-		result.Issues = append(result.Issues, MonitoringIssue{
-			Type:     "Performance",
-			Severity: "WARNING",
-			Description: fmt.Sprintf("Slow searches (>50ms avg) in %d/%d samples, overall avg: %.1fms",
-				slowSearchSamples, result.SampleCount, overallAvgSearchTime),
-			Occurrences: slowSearchSamples,
-			FirstSeen:   result.PerformanceTrend[0].Timestamp,
-			LastSeen:    result.PerformanceTrend[len(result.PerformanceTrend)-1].Timestamp,
-		})
+		result.Issues = append(result.Issues, newMonitoringIssue("Performance", constants.SeverityWarning,
+			fmt.Sprintf("Slow searches (>50ms avg) in %d/%d samples, overall avg: %.1fms",
+				len(slowSearchSeen), result.SampleCount, overallAvgSearchTime),
+			len(slowSearchSeen), slowSearchSeen))
+	}
+}
+
+// analyzeThreadPoolTrends compares consecutive thread pool snapshots and reports every pool
+// whose rejected counter grew between two samples. Rejections from before the window and
+// counters reset by a node restart are not counted.
+func (s *MonitoringService) analyzeThreadPoolTrends(result *models.MonitoringResult) {
+	type rejectionGrowth struct {
+		pool, node string
+		rejected   int64
+		seen       []time.Time
+	}
+	growing := make(map[string]*rejectionGrowth)
+	var keys []string
+	for i := 1; i < len(result.ThreadPoolTrend); i++ {
+		current := &result.ThreadPoolTrend[i]
+		for _, pool := range compareThreadPools(&result.ThreadPoolTrend[i-1], current) {
+			if pool.RejectedDelta <= 0 {
+				continue
+			}
+			key := pool.Node + "/" + pool.Pool
+			g, ok := growing[key]
+			if !ok {
+				g = &rejectionGrowth{pool: pool.Pool, node: pool.Node}
+				growing[key] = g
+				keys = append(keys, key)
+			}
+			g.rejected += pool.RejectedDelta
+			g.seen = append(g.seen, current.Timestamp)
+		}
+	}
+
+	sort.Strings(keys)
+	intervals := len(result.ThreadPoolTrend) - 1
+	for _, key := range keys {
+		g := growing[key]
+		result.Issues = append(result.Issues, newMonitoringIssue("Thread Pool", constants.SeverityWarning,
+			fmt.Sprintf("Rejections in %s on %s grew by %d in %d/%d sampling intervals",
+				g.pool, g.node, g.rejected, len(g.seen), intervals),
+			len(g.seen), g.seen))
+	}
+}
+
+// newMonitoringIssue stamps an issue with the first and last sample it was observed in
+func newMonitoringIssue(issueType, severity, description string, occurrences int, seen []time.Time) models.MonitoringIssue {
+	issue := models.MonitoringIssue{
+		Type:        issueType,
+		Severity:    severity,
+		Description: description,
+		Occurrences: occurrences,
+	}
+	if len(seen) > 0 {
+		issue.FirstSeen = seen[0]
+		issue.LastSeen = seen[len(seen)-1]
+	}
+	return issue
+}
+
+// computeTrendStats builds min/avg/max/p95 for resource usage and per-interval latencies.
+// Latencies come from the delta between consecutive cumulative counters; a single sample
+// falls back to the cumulative average.
+func computeTrendStats(result *models.MonitoringResult) models.TrendStats {
+	var heap, cpu, disk []float64
+	for _, resource := range result.ResourceTrend {
+		heap = append(heap, resource.HeapUsage)
+		cpu = append(cpu, resource.CPUUsage)
+		if resource.DiskTotal > 0 {
+			disk = append(disk, util.CalculatePercentage(resource.DiskTotal-resource.DiskAvailable, resource.DiskTotal))
+		}
+	}
+
+	var queryLatency, indexLatency []float64
+	perf := result.PerformanceTrend
+	if len(perf) == 1 {
+		if perf[0].QueryTotal > 0 {
+			queryLatency = append(queryLatency, float64(perf[0].QueryTimeInMillis)/float64(perf[0].QueryTotal))
+		}
+		if perf[0].IndexTotal > 0 {
+			indexLatency = append(indexLatency, float64(perf[0].IndexTimeInMillis)/float64(perf[0].IndexTotal))
+		}
+	}
+	for i := 1; i < len(perf); i++ {
+		if queries := perf[i].QueryTotal - perf[i-1].QueryTotal; queries > 0 {
+			queryLatency = append(queryLatency, float64(perf[i].QueryTimeInMillis-perf[i-1].QueryTimeInMillis)/float64(queries))
+		}
+		if docs := perf[i].IndexTotal - perf[i-1].IndexTotal; docs > 0 {
+			indexLatency = append(indexLatency, float64(perf[i].IndexTimeInMillis-perf[i-1].IndexTimeInMillis)/float64(docs))
+		}
+	}
+
+	return models.TrendStats{
+		HeapPercent:    summarizeMetric(heap),
+		CPUPercent:     summarizeMetric(cpu),
+		DiskPercent:    summarizeMetric(disk),
+		QueryLatencyMs: summarizeMetric(queryLatency),
+		IndexLatencyMs: summarizeMetric(indexLatency),
+	}
+}
+
+// summarizeMetric uses the nearest-rank method for p95
+func summarizeMetric(values []float64) models.MetricSummary {
+	if len(values) == 0 {
+		return models.MetricSummary{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	rank := int(math.Ceil(0.95*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+
+	return models.MetricSummary{
+		Samples: len(sorted),
+		Min:     sorted[0],
+		Avg:     sum / float64(len(sorted)),
+		Max:     sorted[len(sorted)-1],
+		P95:     sorted[rank],
 	}
 }
 
-func (s *MonitoringService) generateRecommendations(result *MonitoringResult) {
+func (s *MonitoringService) generateRecommendations(result *models.MonitoringResult) {
 	for _, issue := range result.Issues {
 		switch issue.Type {
 		case "Cluster Status":
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/models"
+)
+
+func TestReplayAnalyzesRecordedSamples(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for i, status := range []string{constants.HealthGreen, constants.HealthRed, constants.HealthRed} {
+		ts := start.Add(time.Duration(i) * time.Minute)
+		sample := models.MonitoringSample{
+			ClusterHealth: models.ClusterInfo{Timestamp: ts, Status: status},
+			ResourceUsage: &models.ResourceUsage{Timestamp: ts, HeapUsage: float64(50 + i*10), CPUUsage: 20},
+			Performance: &models.Performance{Timestamp: ts, QueryTotal: int64(100 * (i + 1)),
+				QueryTimeInMillis: int64(1000 * (i + 1))},
+		}
+		if err := enc.Encode(sample); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := NewMonitoringService(nil).Replay(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SampleCount != 3 || result.Duration != 2*time.Minute {
+		t.Fatalf("samples %d duration %v", result.SampleCount, result.Duration)
+	}
+
+	var red *models.MonitoringIssue
+	for i := range result.Issues {
+		if result.Issues[i].Severity == constants.SeverityCritical {
+			red = &result.Issues[i]
+		}
+	}
+	if red == nil || red.Occurrences != 2 || !red.FirstSeen.Equal(start.Add(time.Minute)) {
+		t.Fatalf("red issue: %+v", red)
+	}
+
+	heap := result.Stats.HeapPercent
+	if heap.Min != 50 || heap.Max != 70 || heap.Avg != 60 || heap.P95 != 70 {
+		t.Fatalf("heap stats: %+v", heap)
+	}
+	// 100 queries per interval taking 1000ms each interval
+	if q := result.Stats.QueryLatencyMs; q.Samples != 2 || q.Avg != 10 {
+		t.Fatalf("query latency: %+v", q)
+	}
+}
+
+func TestReplayRejectsMalformedLine(t *testing.T) {
+	if _, err := NewMonitoringService(nil).Replay(strings.NewReader("{\"cluster_health\": {}}\nnot-json\n")); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestReplayReportsGrowingThreadPoolRejections(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	// write rejections grow in the second interval only; search rejections are history
+	for i, rejected := range []int64{5, 5, 45} {
+		ts := start.Add(time.Duration(i) * time.Minute)
+		sample := models.MonitoringSample{
+			ClusterHealth: models.ClusterInfo{Timestamp: ts, Status: constants.HealthGreen},
+			ThreadPools: &models.ThreadPoolSnapshot{Timestamp: ts, Pools: []models.ThreadPoolStats{
+				{Node: "data-1", Pool: "write", Rejected: rejected},
+				{Node: "data-1", Pool: "search", Rejected: 100},
+			}},
+		}
+		if err := enc.Encode(sample); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := NewMonitoringService(nil).Replay(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var issues []models.MonitoringIssue
+	for _, issue := range result.Issues {
+		if issue.Type == "Thread Pool" {
+			issues = append(issues, issue)
+		}
+	}
+	if len(issues) != 1 || issues[0].Occurrences != 1 || !issues[0].FirstSeen.Equal(start.Add(2*time.Minute)) {
+		t.Fatalf("thread pool issues: %+v", issues)
+	}
+	if want := "Rejections in write on data-1 grew by 40 in 1/2 sampling intervals"; issues[0].Description != want {
+		t.Fatalf("description %q, want %q", issues[0].Description, want)
+	}
+}
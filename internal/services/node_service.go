@@ -16,6 +16,7 @@ type NodeService interface {
 	GetNodeBreakdown(ctx context.Context) (*models.NodeBreakdown, error)
 	AnalyzeNodeBalance(ctx context.Context) (*models.BalanceAnalysis, error)
 	GetNodeHealth(ctx context.Context) ([]models.NodeHealth, error)
+	GetDiskWatermarks(ctx context.Context) (*models.DiskWatermarks, error)
 }
 
 type nodeService struct {
@@ -97,9 +98,11 @@ func (s *nodeService) GetNodesInfo(ctx context.Context) ([]models.NodeInfo, erro
 						if total, ok := fs[constants.TotalField].(map[string]interface{}); ok {
 							if totalBytes, ok := total[constants.TotalInBytesField].(float64); ok {
 								nodeInfo.DiskTotal = models.FormatBytes(int64(totalBytes))
+								nodeInfo.DiskTotalBytes = int64(totalBytes)
 							}
 							if availBytes, ok := total[constants.AvailableInBytesField].(float64); ok {
 								nodeInfo.DiskAvail = models.FormatBytes(int64(availBytes))
+								nodeInfo.DiskAvailBytes = int64(availBytes)
 
 								// Calculate disk usage percentage
 								if totalBytes, ok := total[constants.TotalInBytesField].(float64); ok {
@@ -303,6 +306,11 @@ func (s *nodeService) GetNodeHealth(ctx context.Context) ([]models.NodeHealth, e
 		return nil, fmt.Errorf(constants.ErrFailedToGetNodeInfo, err)
 	}
 
+	watermarks, err := s.GetDiskWatermarks(ctx)
+	if err != nil {
+		watermarks = models.DefaultDiskWatermarks()
+	}
+
 	var healthList []models.NodeHealth
 	for _, node := range nodes {
 		health := models.NodeHealth{
@@ -341,17 +349,18 @@ func (s *nodeService) GetNodeHealth(ctx context.Context) ([]models.NodeHealth, e
 			}
 		}
 
-		if node.DiskAvail != constants.EmptyString && node.DiskTotal != constants.EmptyString && node.DiskAvail != constants.DashString && node.DiskTotal != constants.DashString {
-			avail := models.ParseSize(node.DiskAvail)
-			total := models.ParseSize(node.DiskTotal)
-			if total > 0 {
-				used := total - avail
-				diskUsage := float64(used) / float64(total) * constants.HundredMultiplier
-				health.DiskUsage = diskUsage
-				if diskUsage > constants.HighDiskThreshold {
-					health.IsHealthy = false
-					health.Issues = append(health.Issues, constants.MsgHighDiskUsage)
-				}
+		if node.DiskTotalBytes > 0 {
+			used := node.DiskTotalBytes - node.DiskAvailBytes
+			health.DiskUsage = float64(used) / float64(node.DiskTotalBytes) * constants.HundredMultiplier
+
+			highDisk := health.DiskUsage > constants.HighDiskThreshold
+			if node.IsDataNode() {
+				status := watermarks.Evaluate(node.DiskTotalBytes, node.DiskAvailBytes)
+				highDisk = status.Level == models.DiskLevelHigh || status.Level == models.DiskLevelFloodStage
+			}
+			if highDisk {
+				health.IsHealthy = false
+				health.Issues = append(health.Issues, constants.MsgHighDiskUsage)
 			}
 		}
 
@@ -364,3 +373,12 @@ func (s *nodeService) GetNodeHealth(ctx context.Context) ([]models.NodeHealth, e
 
 	return healthList, nil
 }
+
+// GetDiskWatermarks reads the effective disk watermarks from the cluster settings
+func (s *nodeService) GetDiskWatermarks(ctx context.Context) (*models.DiskWatermarks, error) {
+	settings, err := s.client.GetClusterSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrClusterSettingsFailed, err)
+	}
+	return models.DiskWatermarksFromSettings(settings), nil
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/interfaces"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+var (
+	// aggregatableTypes have doc values by default, so cardinality and terms work on them
+	aggregatableTypes = map[string]bool{
+		"keyword": true, "constant_keyword": true, "wildcard": true,
+		"long": true, "integer": true, "short": true, "byte": true, "unsigned_long": true,
+		"double": true, "float": true, "half_float": true, "scaled_float": true,
+		"date": true, "date_nanos": true, "boolean": true, "ip": true, "version": true,
+	}
+	// topValueTypes also get their most frequent values
+	topValueTypes = map[string]bool{"keyword": true, "constant_keyword": true, "wildcard": true}
+)
+
+type ProfileService interface {
+	ProfileIndex(ctx context.Context, indexName string, opts models.ProfileOptions) (*models.IndexProfile, error)
+}
+
+type profileService struct {
+	client       interfaces.ElasticClient
+	indexService IndexService
+}
+
+func NewProfileService(client interfaces.ElasticClient) ProfileService {
+	return &profileService{
+		client:       client,
+		indexService: NewIndexService(client),
+	}
+}
+
+// ProfileIndex profiles every aggregatable field of the index with one search per field, at
+// most opts.Concurrency at a time. Each request gets the configured timeout of its own, so a
+// large index is not cut off as a whole, and a failing field is reported in its row instead
+// of failing the profile.
+func (s *profileService) ProfileIndex(ctx context.Context, indexName string, opts models.ProfileOptions) (*models.IndexProfile, error) {
+	mappingCtx, cancel := util.CreateTimeoutContext()
+	defer cancel()
+	mapping, err := s.indexService.GetIndexFields(mappingCtx, indexName)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, skipped := ProfilableFields(mapping)
+	profile := &models.IndexProfile{
+		Index:      indexName,
+		SampleSize: opts.SampleSize,
+		Fields:     make([]models.FieldProfile, len(fields)),
+		Skipped:    skipped,
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, field := range fields {
+		if ctx.Err() != nil {
+			profile.Fields[i] = models.FieldProfile{Field: field.Path, Type: field.Type, Error: ctx.Err().Error()}
+			continue
+		}
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, field models.FieldMapping) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			profile.Fields[i] = s.profileField(indexName, field, opts)
+		}(i, field)
+	}
+	wg.Wait()
+	return profile, nil
+}
+
+func (s *profileService) profileField(indexName string, field models.FieldMapping, opts models.ProfileOptions) models.FieldProfile {
+	result := models.FieldProfile{Field: field.Path, Type: field.Type}
+
+	body, err := buildFieldProfileBody(field.Path, topValueTypes[field.Type], opts.TopValues, opts.SampleSize)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	fieldCtx, cancel := util.CreateTimeoutContext()
+	defer cancel()
+	resp, err := s.client.SearchWithBody(fieldCtx, indexName, body)
+	if err != nil {
+		result.Error = fmt.Errorf(constants.ErrFieldProfileFailed, err).Error()
+		return result
+	}
+	parseFieldProfile(resp, opts.SampleSize > 0, &result)
+	return result
+}
+
+// ProfilableFields returns the mapped fields that can be aggregated, sorted by path, and the
+// number of fields left out. Fields inside nested objects need a nested aggregation and are
+// left out too.
+func ProfilableFields(mapping []models.FieldMapping) ([]models.FieldMapping, int) {
+	var nestedPaths []string
+	for _, f := range mapping {
+		if f.Type == "nested" {
+			nestedPaths = append(nestedPaths, f.Path+".")
+		}
+	}
+
+	fields := make([]models.FieldMapping, 0, len(mapping))
+	skipped := 0
+	for _, f := range mapping {
+		insideNested := false
+		for _, p := range nestedPaths {
+			if strings.HasPrefix(f.Path, p) {
+				insideNested = true
+				break
+			}
+		}
+		if !aggregatableTypes[f.Type] || insideNested {
+			skipped++
+			continue
+		}
+		fields = append(fields, f)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Path < fields[j].Path
+	})
+	return fields, skipped
+}
+
+// buildFieldProfileBody asks for the documents with the field, the approximate cardinality
+// and, for keywords, the top values. With sampleSize > 0 the aggregations run inside a
+// sampler that reads at most that many documents per shard.
+func buildFieldProfileBody(field string, withTopValues bool, topValues, sampleSize int) ([]byte, error) {
+	aggs := map[string]interface{}{
+		"has_field": map[string]interface{}{
+			"filter": map[string]interface{}{"exists": map[string]interface{}{"field": field}},
+		},
+		"distinct": map[string]interface{}{
+			"cardinality": map[string]interface{}{"field": field},
+		},
+	}
+	if withTopValues && topValues > 0 {
+		aggs["top_values"] = map[string]interface{}{
+			"terms": map[string]interface{}{"field": field, "size": topValues},
+		}
+	}
+
+	body := map[string]interface{}{"size": 0}
+	if sampleSize > 0 {
+		body["track_total_hits"] = false
+		body["aggs"] = map[string]interface{}{
+			"sample": map[string]interface{}{
+				"sampler": map[string]interface{}{"shard_size": sampleSize},
+				"aggs":    aggs,
+			},
+		}
+	} else {
+		body["track_total_hits"] = true
+		body["aggs"] = aggs
+	}
+	return json.Marshal(body)
+}
+
+func parseFieldProfile(resp map[string]interface{}, sampled bool, result *models.FieldProfile) {
+	aggs, _ := resp["aggregations"].(map[string]interface{})
+	if sampled {
+		sample, _ := aggs["sample"].(map[string]interface{})
+		result.Docs = int64Field(sample, "doc_count")
+		aggs = sample
+	} else if hits, ok := resp["hits"].(map[string]interface{}); ok {
+		if total, ok := hits["total"].(map[string]interface{}); ok {
+			result.Docs = int64Field(total, "value")
+		}
+	}
+	if aggs == nil {
+		result.Error = "no aggregations in search response"
+		return
+	}
+
+	if hasField, ok := aggs["has_field"].(map[string]interface{}); ok {
+		result.DocsWithField = int64Field(hasField, "doc_count")
+	}
+	if result.Docs > 0 {
+		result.Coverage = float64(result.DocsWithField) / float64(result.Docs) * constants.HundredMultiplier
+	}
+	if distinct, ok := aggs["distinct"].(map[string]interface{}); ok {
+		result.Cardinality = int64Field(distinct, "value")
+	}
+	if top, ok := aggs["top_values"].(map[string]interface{}); ok {
+		buckets, _ := top["buckets"].([]interface{})
+		for _, b := range buckets {
+			bucket, ok := b.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			value := util.GetStringField(bucket, "key_as_string")
+			if value == "" {
+				value = fmt.Sprintf("%v", bucket["key"])
+			}
+			result.TopValues = append(result.TopValues, models.FieldValueCount{Value: value, Count: int64Field(bucket, "doc_count")})
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mertbahardogan/escope/internal/models"
+)
+
+func TestProfilableFieldsSkipsTextAndNested(t *testing.T) {
+	mapping := mappingFields(t, `{
+	  "status":   {"type": "keyword"},
+	  "message":  {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+	  "took":     {"type": "long"},
+	  "user":     {"properties": {"id": {"type": "keyword"}}},
+	  "comments": {"type": "nested", "properties": {"author": {"type": "keyword"}}}}`)
+
+	fields, skipped := ProfilableFields(mapping)
+	var paths []string
+	for _, f := range fields {
+		paths = append(paths, f.Path)
+	}
+	if strings.Join(paths, ",") != "message.keyword,status,took,user.id" || skipped != 4 {
+		t.Fatalf("fields: %v, skipped: %d", paths, skipped)
+	}
+}
+
+func TestParseFieldProfileSampled(t *testing.T) {
+	body, err := buildFieldProfileBody("status", true, 2, 500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), `"sampler":{"shard_size":500}`) || !strings.Contains(string(body), `"top_values"`) {
+		t.Fatalf("body: %s", body)
+	}
+
+	var resp map[string]interface{}
+	raw := `{"hits": {"total": {"value": 0}}, "aggregations": {"sample": {"doc_count": 1000,
+	  "has_field": {"doc_count": 750},
+	  "distinct":  {"value": 3},
+	  "top_values": {"buckets": [{"key": "ok", "doc_count": 600}, {"key": "error", "doc_count": 100}]}}}}`
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatal(err)
+	}
+	result := models.FieldProfile{Field: "status", Type: "keyword"}
+	parseFieldProfile(resp, true, &result)
+	if result.Docs != 1000 || result.Coverage != 75 || result.Cardinality != 3 || len(result.TopValues) != 2 || result.TopValues[0].Value != "ok" {
+		t.Fatalf("profile: %+v", result)
+	}
+}
@@ -0,0 +1,385 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/interfaces"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+var (
+	// searchBodyKeys are the top level keys of a search request; a query file without any of
+	// them is taken to be a bare query
+	searchBodyKeys = []string{"query", "aggs", "aggregations", "size", "from", "sort", "_source", "post_filter",
+		"knn", "highlight", "track_total_hits", "min_score", "timeout", "search_after", "runtime_mappings",
+		"collapse", "rescore", "suggest", "fields", "stored_fields", "docvalue_fields", "script_fields",
+		"profile", "explain", "terminate_after", "pit"}
+
+	// [nodeId][index][0]
+	profileShardID = regexp.MustCompile(`^\[([^\]]*)\]\[([^\]]*)\]\[(\d+)\]$`)
+)
+
+type SearchService interface {
+	Search(ctx context.Context, indexName string, body []byte, fields []string) (*models.SearchResult, error)
+	Explain(ctx context.Context, indexName, documentID string, body []byte) (*models.QueryExplanation, error)
+	Validate(ctx context.Context, indexName string, body []byte) (*models.QueryValidation, error)
+}
+
+type searchService struct {
+	client interfaces.ElasticClient
+}
+
+func NewSearchService(client interfaces.ElasticClient) SearchService {
+	return &searchService{
+		client: client,
+	}
+}
+
+// Search runs the request body and summarises the response; fields picks the _source values
+// shown per hit
+func (s *searchService) Search(ctx context.Context, indexName string, body []byte, fields []string) (*models.SearchResult, error) {
+	resp, err := s.client.SearchWithBody(ctx, indexName, body)
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrSearchFailed, err)
+	}
+	return parseSearchResult(resp, fields), nil
+}
+
+// Explain asks how the document scores against the query, or why it does not match
+func (s *searchService) Explain(ctx context.Context, indexName, documentID string, body []byte) (*models.QueryExplanation, error) {
+	resp, err := s.client.Explain(ctx, indexName, documentID, body)
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrExplainFailed, err)
+	}
+	explanation := parseQueryExplanation(resp)
+	if !explanation.Found {
+		return nil, fmt.Errorf(constants.ErrDocumentNotFound, documentID, indexName)
+	}
+	return explanation, nil
+}
+
+// Validate checks that the query parses and returns the Lucene query it is rewritten to
+func (s *searchService) Validate(ctx context.Context, indexName string, body []byte) (*models.QueryValidation, error) {
+	resp, err := s.client.ValidateQuery(ctx, indexName, body)
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrValidateQueryFailed, err)
+	}
+	return parseQueryValidation(resp), nil
+}
+
+// LoadSearchBody returns the search request from a JSON file ("-" reads stdin), or a
+// query_string query for a Lucene query string, or match_all when both are empty. A file
+// holding only a query, without the surrounding {"query": ...}, is wrapped.
+func LoadSearchBody(queryFile, queryString string) (map[string]interface{}, error) {
+	if queryFile != "" && queryString != "" {
+		return nil, fmt.Errorf("%s", constants.ErrSearchQueryConflict)
+	}
+	if queryString != "" {
+		return map[string]interface{}{
+			"query": map[string]interface{}{"query_string": map[string]interface{}{"query": queryString}},
+		}, nil
+	}
+	if queryFile == "" {
+		return map[string]interface{}{"query": map[string]interface{}{"match_all": map[string]interface{}{}}}, nil
+	}
+
+	var data []byte
+	var err error
+	if queryFile == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(queryFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrSearchQueryRead, err)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, fmt.Errorf(constants.ErrInvalidQueryJSON, err)
+	}
+	for _, key := range searchBodyKeys {
+		if _, ok := body[key]; ok {
+			return body, nil
+		}
+	}
+	return map[string]interface{}{"query": body}, nil
+}
+
+// BuildSearchBody applies the command line options to a search request. A negative size
+// keeps the size of the request; fields limit _source to those fields.
+func BuildSearchBody(body map[string]interface{}, size int, fields []string, profile bool) ([]byte, error) {
+	if size >= 0 {
+		body["size"] = size
+	}
+	if len(fields) > 0 {
+		body["_source"] = fields
+	}
+	if profile {
+		body["profile"] = true
+	}
+	return json.Marshal(body)
+}
+
+// QueryOnlyBody keeps just the query of a search request, as the explain and validate APIs
+// accept nothing else
+func QueryOnlyBody(body map[string]interface{}) ([]byte, error) {
+	query, ok := body["query"]
+	if !ok {
+		query = map[string]interface{}{"match_all": map[string]interface{}{}}
+	}
+	return json.Marshal(map[string]interface{}{"query": query})
+}
+
+func parseSearchResult(resp map[string]interface{}, fields []string) *models.SearchResult {
+	result := &models.SearchResult{
+		Took:          int64Field(resp, "took"),
+		TotalRelation: "eq",
+		Failures:      make([]models.ShardFailure, 0),
+		Hits:          make([]models.SearchHit, 0),
+	}
+	result.TimedOut, _ = resp["timed_out"].(bool)
+
+	if shards, ok := resp["_shards"].(map[string]interface{}); ok {
+		result.Shards = models.SearchShards{
+			Total:      int(int64Field(shards, "total")),
+			Successful: int(int64Field(shards, "successful")),
+			Skipped:    int(int64Field(shards, "skipped")),
+			Failed:     int(int64Field(shards, "failed")),
+		}
+		failures, _ := shards["failures"].([]interface{})
+		for _, f := range failures {
+			failure, ok := f.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			sf := models.ShardFailure{
+				Index: util.GetStringField(failure, "index"),
+				Shard: int(int64Field(failure, "shard")),
+				Node:  util.GetStringField(failure, "node"),
+			}
+			if reason, ok := failure["reason"].(map[string]interface{}); ok {
+				sf.Reason = util.GetStringField(reason, "type") + ": " + util.GetStringField(reason, "reason")
+			}
+			result.Failures = append(result.Failures, sf)
+		}
+	}
+
+	if hits, ok := resp["hits"].(map[string]interface{}); ok {
+		switch total := hits["total"].(type) {
+		case map[string]interface{}:
+			result.TotalHits = int64Field(total, "value")
+			if relation := util.GetStringField(total, "relation"); relation != "" {
+				result.TotalRelation = relation
+			}
+		case float64:
+			result.TotalHits = int64(total)
+		}
+		list, _ := hits["hits"].([]interface{})
+		for _, h := range list {
+			hit, ok := h.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			sh := models.SearchHit{Index: util.GetStringField(hit, "_index"), ID: util.GetStringField(hit, "_id")}
+			sh.Score, _ = hit["_score"].(float64)
+			source, _ := hit["_source"].(map[string]interface{})
+			if len(fields) == 0 {
+				sh.Source = source
+			} else {
+				sh.Fields = make(map[string]string, len(fields))
+				for _, field := range fields {
+					sh.Fields[field] = sourceValue(source, field)
+				}
+			}
+			result.Hits = append(result.Hits, sh)
+		}
+	}
+
+	if profile, ok := resp["profile"].(map[string]interface{}); ok {
+		result.Profile = parseSearchProfile(profile)
+	}
+	return result
+}
+
+// sourceValue reads a dotted path from _source, following both nested objects and dotted
+// keys; arrays are joined and a missing field is "-"
+func sourceValue(source map[string]interface{}, path string) string {
+	if v, ok := source[path]; ok {
+		return formatSourceValue(v)
+	}
+	for i := 0; i < len(path); i++ {
+		if path[i] != '.' {
+			continue
+		}
+		if child, ok := source[path[:i]].(map[string]interface{}); ok {
+			if v := sourceValue(child, path[i+1:]); v != constants.DashString {
+				return v
+			}
+		}
+	}
+	return constants.DashString
+}
+
+func formatSourceValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return constants.DashString
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case []interface{}:
+		parts := make([]string, 0, len(value))
+		for _, item := range value {
+			parts = append(parts, formatSourceValue(item))
+		}
+		return strings.Join(parts, ", ")
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// parseSearchProfile reads the profile section, slowest shard first
+func parseSearchProfile(profile map[string]interface{}) []models.ShardProfile {
+	shards, _ := profile["shards"].([]interface{})
+	result := make([]models.ShardProfile, 0, len(shards))
+	for _, s := range shards {
+		shard, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		sp := models.ShardProfile{
+			ID:           util.GetStringField(shard, "id"),
+			Queries:      make([]models.ProfileNode, 0),
+			Collectors:   make([]models.ProfileNode, 0),
+			Aggregations: parseProfileNodes(shard["aggregations"]),
+		}
+		if m := profileShardID.FindStringSubmatch(sp.ID); m != nil {
+			sp.Node, sp.Index = m[1], m[2]
+			sp.Shard, _ = strconv.Atoi(m[3])
+		}
+
+		searches, _ := shard["searches"].([]interface{})
+		for _, se := range searches {
+			search, ok := se.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			queries := parseProfileNodes(search["query"])
+			for _, q := range queries {
+				sp.QueryNanos += q.TimeNanos
+			}
+			sp.Queries = append(sp.Queries, queries...)
+			sp.RewriteNanos += int64Field(search, "rewrite_time")
+			sp.Collectors = append(sp.Collectors, parseProfileNodes(search["collector"])...)
+		}
+		if fetch, ok := shard["fetch"].(map[string]interface{}); ok {
+			sp.FetchNanos = int64Field(fetch, "time_in_nanos")
+		}
+		result = append(result, sp)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].QueryNanos > result[j].QueryNanos
+	})
+	return result
+}
+
+func parseProfileNodes(data interface{}) []models.ProfileNode {
+	list, _ := data.([]interface{})
+	nodes := make([]models.ProfileNode, 0, len(list))
+	for _, item := range list {
+		node, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pn := models.ProfileNode{
+			Type:        util.GetStringField(node, "type"),
+			Description: util.GetStringField(node, "description"),
+			TimeNanos:   int64Field(node, "time_in_nanos"),
+			Children:    parseProfileNodes(node["children"]),
+		}
+		// Collectors carry a name and reason instead of a type and description
+		if pn.Type == "" {
+			pn.Type = util.GetStringField(node, "name")
+			pn.Description = util.GetStringField(node, "reason")
+		}
+		if breakdown, ok := node["breakdown"].(map[string]interface{}); ok {
+			pn.Breakdown = make(map[string]int64)
+			for key := range breakdown {
+				if !strings.HasSuffix(key, "_count") {
+					pn.Breakdown[key] = int64Field(breakdown, key)
+				}
+			}
+		}
+		nodes = append(nodes, pn)
+	}
+	return nodes
+}
+
+func parseQueryExplanation(resp map[string]interface{}) *models.QueryExplanation {
+	explanation := &models.QueryExplanation{
+		Index: util.GetStringField(resp, "_index"),
+		ID:    util.GetStringField(resp, "_id"),
+		Found: true,
+	}
+	if found, ok := resp["found"].(bool); ok {
+		explanation.Found = found
+	}
+	explanation.Matched, _ = resp["matched"].(bool)
+	if node, ok := resp["explanation"].(map[string]interface{}); ok {
+		parsed := parseExplainNode(node)
+		explanation.Explanation = &parsed
+	}
+	return explanation
+}
+
+func parseExplainNode(node map[string]interface{}) models.ExplainNode {
+	parsed := models.ExplainNode{Description: util.GetStringField(node, "description")}
+	parsed.Value, _ = node["value"].(float64)
+	details, _ := node["details"].([]interface{})
+	for _, d := range details {
+		if detail, ok := d.(map[string]interface{}); ok {
+			parsed.Details = append(parsed.Details, parseExplainNode(detail))
+		}
+	}
+	return parsed
+}
+
+func parseQueryValidation(resp map[string]interface{}) *models.QueryValidation {
+	validation := &models.QueryValidation{Explanations: make([]models.ValidationExplanation, 0)}
+	validation.Valid, _ = resp["valid"].(bool)
+	explanations, _ := resp["explanations"].([]interface{})
+	for _, e := range explanations {
+		explanation, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ve := models.ValidationExplanation{
+			Index:       util.GetStringField(explanation, "index"),
+			Explanation: util.GetStringField(explanation, "explanation"),
+			Error:       util.GetStringField(explanation, "error"),
+		}
+		ve.Valid, _ = explanation["valid"].(bool)
+		validation.Explanations = append(validation.Explanations, ve)
+	}
+	// Without per-index explanations the top level error is all there is
+	if !validation.Valid && len(validation.Explanations) == 0 {
+		if reason := util.GetStringField(resp, "error"); reason != "" {
+			validation.Explanations = append(validation.Explanations, models.ValidationExplanation{Error: reason})
+		}
+	}
+	return validation
+}
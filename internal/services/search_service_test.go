@@ -0,0 +1,132 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadSearchBody(t *testing.T) {
+	body, err := LoadSearchBody("", "status:500")
+	if err != nil || body["query"].(map[string]interface{})["query_string"] == nil {
+		t.Fatalf("query string: %v %v", body, err)
+	}
+	if _, err := LoadSearchBody("q.json", "status:500"); err == nil {
+		t.Fatal("file and query string together must fail")
+	}
+
+	dir := t.TempDir()
+	bare := filepath.Join(dir, "bare.json")
+	if err := os.WriteFile(bare, []byte(`{"term": {"status": 500}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	body, err = LoadSearchBody(bare, "")
+	if err != nil || body["query"].(map[string]interface{})["term"] == nil {
+		t.Fatalf("bare query must be wrapped: %v %v", body, err)
+	}
+
+	data, err := BuildSearchBody(body, -1, []string{"status", "host.name"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"size"`) || !strings.Contains(string(data), `"_source":["status","host.name"]`) ||
+		!strings.Contains(string(data), `"profile":true`) {
+		t.Fatalf("body: %s", data)
+	}
+}
+
+func TestParseSearchResult(t *testing.T) {
+	var resp map[string]interface{}
+	raw := `{"took": 12, "timed_out": false,
+	  "_shards": {"total": 2, "successful": 1, "skipped": 0, "failed": 1, "failures": [
+	    {"shard": 1, "index": "logs", "node": "n1", "reason": {"type": "query_shard_exception", "reason": "bad field"}}]},
+	  "hits": {"total": {"value": 10000, "relation": "gte"}, "hits": [
+	    {"_index": "logs", "_id": "1", "_score": 1.5, "_source": {"host": {"name": "web-1"}, "tags": ["a", "b"], "user.id": 7}}]},
+	  "profile": {"shards": [
+	    {"id": "[n1][logs][0]", "searches": [{"rewrite_time": 100, "query": [
+	      {"type": "BooleanQuery", "description": "+status:500", "time_in_nanos": 1000,
+	       "breakdown": {"score": 600, "score_count": 3, "next_doc": 400},
+	       "children": [{"type": "TermQuery", "description": "status:500", "time_in_nanos": 800}]}],
+	      "collector": [{"name": "SimpleTopScoreDocCollector", "reason": "search_top_hits", "time_in_nanos": 50}]}],
+	     "fetch": {"time_in_nanos": 30}},
+	    {"id": "[n2][logs][1]", "searches": [{"query": [{"type": "TermQuery", "time_in_nanos": 5000}]}]}]}}`
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	result := parseSearchResult(resp, []string{"host.name", "tags", "user.id", "missing"})
+	if result.Took != 12 || result.TotalHits != 10000 || result.TotalRelation != "gte" || result.Shards.Failed != 1 {
+		t.Fatalf("summary: %+v", result)
+	}
+	if len(result.Failures) != 1 || result.Failures[0].Reason != "query_shard_exception: bad field" {
+		t.Fatalf("failures: %+v", result.Failures)
+	}
+	fields := result.Hits[0].Fields
+	if fields["host.name"] != "web-1" || fields["tags"] != "a, b" || fields["user.id"] != "7" || fields["missing"] != "-" {
+		t.Fatalf("fields: %+v", fields)
+	}
+
+	if len(result.Profile) != 2 || result.Profile[0].Node != "n2" {
+		t.Fatalf("profile must be ordered by query time: %+v", result.Profile)
+	}
+	shard := result.Profile[1]
+	if shard.Index != "logs" || shard.Shard != 0 || shard.QueryNanos != 1000 || shard.RewriteNanos != 100 || shard.FetchNanos != 30 {
+		t.Fatalf("shard: %+v", shard)
+	}
+	query := shard.Queries[0]
+	if len(query.Breakdown) != 2 || query.Breakdown["score"] != 600 || len(query.Children) != 1 {
+		t.Fatalf("query: %+v", query)
+	}
+	if shard.Collectors[0].Type != "SimpleTopScoreDocCollector" {
+		t.Fatalf("collectors: %+v", shard.Collectors)
+	}
+}
+
+func TestParseQueryExplanation(t *testing.T) {
+	var resp map[string]interface{}
+	raw := `{"_index": "docs", "_id": "1", "matched": true, "explanation": {"value": 1.5, "description": "sum of:", "details": [
+	  {"value": 1.5, "description": "weight(title:search in 0)", "details": [{"value": 2.2, "description": "boost"}]},
+	  {"value": 0, "description": "match on required clause"}]}}`
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatal(err)
+	}
+	explanation := parseQueryExplanation(resp)
+	if !explanation.Found || !explanation.Matched || explanation.Explanation == nil {
+		t.Fatalf("explanation: %+v", explanation)
+	}
+	root := explanation.Explanation
+	if root.Value != 1.5 || len(root.Details) != 2 || root.Details[0].Details[0].Description != "boost" {
+		t.Fatalf("tree: %+v", root)
+	}
+
+	missing := parseQueryExplanation(map[string]interface{}{"_index": "docs", "_id": "2", "matched": false, "found": false})
+	if missing.Found || missing.Explanation != nil {
+		t.Fatalf("missing document: %+v", missing)
+	}
+}
+
+func TestParseQueryValidation(t *testing.T) {
+	var resp map[string]interface{}
+	raw := `{"valid": false, "explanations": [
+	  {"index": "a", "valid": true, "explanation": "+status:500 #@timestamp:[1 TO 2]"},
+	  {"index": "b", "valid": false, "error": "failed to create query"}]}`
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatal(err)
+	}
+	validation := parseQueryValidation(resp)
+	if validation.Valid || len(validation.Explanations) != 2 || validation.Explanations[1].Error != "failed to create query" {
+		t.Fatalf("validation: %+v", validation)
+	}
+
+	bare := parseQueryValidation(map[string]interface{}{"valid": false, "error": "ParsingException: unknown query [mtch]"})
+	if len(bare.Explanations) != 1 || bare.Explanations[0].Error == "" {
+		t.Fatalf("top level error: %+v", bare)
+	}
+
+	body, err := QueryOnlyBody(map[string]interface{}{"size": 5, "query": map[string]interface{}{"term": map[string]interface{}{"a": 1}}})
+	if err != nil || string(body) != `{"query":{"term":{"a":1}}}` {
+		t.Fatalf("query only body: %s %v", body, err)
+	}
+}
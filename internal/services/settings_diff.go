@@ -0,0 +1,120 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// volatileSettings differ between any two indices and are never compared
+var volatileSettings = []string{"index.uuid", "index.creation_date", "index.provided_name", "index.version"}
+
+// IsVolatileSetting reports whether key is set by Elasticsearch per index (uuid, creation
+// date, provided name, version.*)
+func IsVolatileSetting(key string) bool {
+	for _, v := range volatileSettings {
+		if key == v || strings.HasPrefix(key, v+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffIndexSettings compares two flattened settings lists, ignoring volatile keys. Changes
+// are sorted by key.
+func DiffIndexSettings(left, right []models.IndexSettingInfo) []models.SettingChange {
+	leftByKey := settingsByKey(left)
+	rightByKey := settingsByKey(right)
+
+	changes := make([]models.SettingChange, 0)
+	for key, l := range leftByKey {
+		r, ok := rightByKey[key]
+		if !ok {
+			r = "-"
+		}
+		if l != r {
+			changes = append(changes, models.SettingChange{Key: key, Left: l, Right: r})
+		}
+	}
+	for key, r := range rightByKey {
+		if _, ok := leftByKey[key]; !ok {
+			changes = append(changes, models.SettingChange{Key: key, Left: "-", Right: r})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+// VerifyIndexSettings checks the live settings of one index against the desired state. Only
+// the keys of the desired state are checked, so it can pin a few settings.
+func VerifyIndexSettings(desired map[string]string, actual []models.IndexSettingInfo) []models.SettingDrift {
+	actualByKey := settingsByKey(actual)
+
+	drift := make([]models.SettingDrift, 0)
+	for key, expected := range desired {
+		if IsVolatileSetting(key) {
+			continue
+		}
+		value, ok := actualByKey[key]
+		if !ok {
+			value = "-"
+		}
+		if value != expected {
+			drift = append(drift, models.SettingDrift{Key: key, Expected: expected, Actual: value})
+		}
+	}
+
+	sort.Slice(drift, func(i, j int) bool {
+		return drift[i].Key < drift[j].Key
+	})
+	return drift
+}
+
+// LoadDesiredSettings reads a desired-state file in YAML or JSON. The settings may be nested
+// or flat, optionally under a top-level "settings" key as in the create index API; keys
+// without the "index." prefix get it, so number_of_replicas and index.number_of_replicas
+// are the same setting.
+func LoadDesiredSettings(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrDesiredSettingsRead, err)
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf(constants.ErrDesiredSettingsParse, err)
+	}
+	if settings, ok := doc["settings"].(map[string]interface{}); ok {
+		doc = settings
+	}
+
+	desired := make(map[string]string)
+	for _, s := range flattenSettings(doc, "") {
+		key := s.Key
+		if !strings.HasPrefix(key, "index.") {
+			key = "index." + key
+		}
+		desired[key] = s.Value
+	}
+	if len(desired) == 0 {
+		return nil, fmt.Errorf(constants.ErrDesiredSettingsEmpty, path)
+	}
+	return desired, nil
+}
+
+func settingsByKey(settings []models.IndexSettingInfo) map[string]string {
+	byKey := make(map[string]string, len(settings))
+	for _, s := range settings {
+		if !IsVolatileSetting(s.Key) {
+			byKey[s.Key] = s.Value
+		}
+	}
+	return byKey
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mertbahardogan/escope/internal/models"
+)
+
+func TestDiffIndexSettingsIgnoresVolatileKeys(t *testing.T) {
+	left := []models.IndexSettingInfo{
+		{Key: "index.uuid", Value: "a"},
+		{Key: "index.creation_date", Value: "1"},
+		{Key: "index.version.created", Value: "8000099"},
+		{Key: "index.number_of_shards", Value: "3"},
+		{Key: "index.refresh_interval", Value: "1s"},
+	}
+	right := []models.IndexSettingInfo{
+		{Key: "index.uuid", Value: "b"},
+		{Key: "index.creation_date", Value: "2"},
+		{Key: "index.version.created", Value: "8500099"},
+		{Key: "index.number_of_shards", Value: "3"},
+		{Key: "index.codec", Value: "best_compression"},
+	}
+
+	changes := DiffIndexSettings(left, right)
+	want := []models.SettingChange{
+		{Key: "index.codec", Left: "-", Right: "best_compression"},
+		{Key: "index.refresh_interval", Left: "1s", Right: "-"},
+	}
+	if len(changes) != len(want) || changes[0] != want[0] || changes[1] != want[1] {
+		t.Fatalf("changes: %+v", changes)
+	}
+}
+
+func TestVerifyIndexSettingsAgainstDesiredFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "desired.yaml")
+	content := "settings:\n  number_of_replicas: 1\n  index:\n    refresh_interval: 30s\n  index.codec: best_compression\n  index.uuid: ignored\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	desired, err := LoadDesiredSettings(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if desired["index.number_of_replicas"] != "1" || desired["index.refresh_interval"] != "30s" || len(desired) != 4 {
+		t.Fatalf("desired: %v", desired)
+	}
+
+	live := []models.IndexSettingInfo{
+		{Key: "index.uuid", Value: "x"},
+		{Key: "index.number_of_replicas", Value: "1"},
+		{Key: "index.refresh_interval", Value: "1s"},
+	}
+	drift := VerifyIndexSettings(desired, live)
+	want := []models.SettingDrift{
+		{Key: "index.codec", Expected: "best_compression", Actual: "-"},
+		{Key: "index.refresh_interval", Expected: "30s", Actual: "1s"},
+	}
+	if len(drift) != len(want) || drift[0] != want[0] || drift[1] != want[1] {
+		t.Fatalf("drift: %+v", drift)
+	}
+}
+
+func TestVerifyIndexSettingsAcceptsDefaults(t *testing.T) {
+	var resp map[string]interface{}
+	raw := `{"logs-1": {
+	  "settings": {"index": {"number_of_shards": "1", "number_of_replicas": "2", "uuid": "abc"}},
+	  "defaults": {"index": {"refresh_interval": "1s", "number_of_replicas": "1", "codec": "default"}}}}`
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatal(err)
+	}
+	live := parseIndicesSettings(resp)["logs-1"]
+
+	// refresh_interval was never set on the index: its default still matches the desired state
+	desired := map[string]string{"index.refresh_interval": "1s", "index.number_of_replicas": "2"}
+	if drift := VerifyIndexSettings(desired, live); len(drift) != 0 {
+		t.Fatalf("defaults must count as the live value: %+v", drift)
+	}
+
+	desired = map[string]string{"index.number_of_replicas": "1", "index.codec": "best_compression"}
+	drift := VerifyIndexSettings(desired, live)
+	if len(drift) != 2 || drift[0].Actual != "default" || drift[1].Actual != "2" {
+		t.Fatalf("explicit settings must win over defaults: %+v", drift)
+	}
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+// deciderHints turns the allocation deciders that most often block a shard into next steps
+var deciderHints = map[string]string{
+	"disk_threshold":               "Node disk is above a watermark: free disk space, add data nodes or review cluster.routing.allocation.disk.watermark.*",
+	"same_shard":                   "A copy of this shard already lives on every eligible node: add data nodes or lower index.number_of_replicas",
+	"awareness":                    "Allocation awareness has no zone left for this copy: check cluster.routing.allocation.awareness.* and the node attributes per zone",
+	"filter":                       "Allocation filters exclude the nodes: review index.routing.allocation.* and cluster.routing.allocation.include/exclude/require",
+	"max_retry":                    "Allocation failed too many times: fix the failure in unassigned details, then run POST _cluster/reroute?retry_failed=true",
+	"shards_limit":                 "Shards-per-node limit reached: raise index.routing.allocation.total_shards_per_node or cluster.routing.allocation.total_shards_per_node",
+	"enable":                       "Allocation is disabled: check cluster.routing.allocation.enable and index.routing.allocation.enable",
+	"node_version":                 "The target nodes run an older version than the shard's source: finish the rolling upgrade",
+	"data_tier":                    "No node in the index's preferred data tier can hold it: add nodes to the tier or review index.routing.allocation.include._tier_preference",
+	"throttling":                   "Recoveries are throttled: the shard will be allocated once running recoveries finish",
+	"replica_after_primary_active": "The primary is not active yet: resolve the primary first",
+}
+
+// ExplainAllocation explains why a shard is (not) allocated; a nil req explains the first unassigned shard
+func (s *shardService) ExplainAllocation(ctx context.Context, req *models.AllocationExplainRequest) (*models.AllocationExplain, error) {
+	var body []byte
+	if req != nil && req.Index != "" {
+		var err error
+		body, err = json.Marshal(map[string]interface{}{"index": req.Index, "shard": req.Shard, "primary": req.Primary})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := s.client.GetAllocationExplain(ctx, body)
+	if err != nil {
+		if body == nil && strings.Contains(err.Error(), "unable to find any unassigned shards") {
+			return nil, fmt.Errorf(constants.ErrNoUnassignedShards)
+		}
+		return nil, fmt.Errorf(constants.ErrAllocationExplainFailed, err)
+	}
+	return parseAllocationExplain(data), nil
+}
+
+func parseAllocationExplain(data map[string]interface{}) *models.AllocationExplain {
+	explain := &models.AllocationExplain{
+		Index:                util.GetStringField(data, "index"),
+		CurrentState:         util.GetStringField(data, "current_state"),
+		CanAllocate:          util.GetStringField(data, "can_allocate"),
+		Explanation:          util.GetStringField(data, "allocate_explanation"),
+		BlockingDeciderNodes: make(map[string]int),
+	}
+	if shard, ok := data["shard"].(float64); ok {
+		explain.Shard = int(shard)
+	}
+	explain.Primary, _ = data["primary"].(bool)
+	if node, ok := data["current_node"].(map[string]interface{}); ok {
+		explain.CurrentNode = util.GetStringField(node, "name")
+	}
+	if info, ok := data["unassigned_info"].(map[string]interface{}); ok {
+		explain.UnassignedReason = util.GetStringField(info, "reason")
+		explain.UnassignedSince = util.GetStringField(info, "at")
+		explain.UnassignedDetails = util.GetStringField(info, "details")
+		if attempts, ok := info["failed_allocation_attempts"].(float64); ok {
+			explain.FailedAttempts = int(attempts)
+		}
+	}
+	if explain.Explanation == "" {
+		// Assigned shards explain whether they can stay and rebalance instead
+		explain.Explanation = util.GetStringField(data, "rebalance_explanation")
+	}
+
+	decisions, _ := data["node_allocation_decisions"].([]interface{})
+	for _, d := range decisions {
+		node, ok := d.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		decision := models.NodeAllocationDecision{
+			NodeName: util.GetStringField(node, "node_name"),
+			Decision: util.GetStringField(node, "node_decision"),
+		}
+		deciders, _ := node["deciders"].([]interface{})
+		for _, dd := range deciders {
+			decider, ok := dd.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			verdict := util.GetStringField(decider, "decision")
+			if strings.EqualFold(verdict, "YES") {
+				continue
+			}
+			name := util.GetStringField(decider, "decider")
+			decision.Deciders = append(decision.Deciders, models.AllocationDecider{
+				Decider:     name,
+				Decision:    verdict,
+				Explanation: util.GetStringField(decider, "explanation"),
+			})
+			explain.BlockingDeciderNodes[name]++
+		}
+		explain.NodeDecisions = append(explain.NodeDecisions, decision)
+	}
+
+	explain.Hints = allocationHints(explain)
+	return explain
+}
+
+// allocationHints lists remediation steps, most blocking decider first
+func allocationHints(explain *models.AllocationExplain) []string {
+	var hints []string
+	switch explain.CanAllocate {
+	case "no_valid_shard_copy":
+		hints = append(hints, "No valid copy of this primary is left: bring back the node that held it, restore from a snapshot, or accept data loss with allocate_stale_primary / allocate_empty_primary")
+	case "allocation_delayed":
+		hints = append(hints, "Allocation is delayed while the node that left may return (index.unassigned.node_left.delayed_timeout)")
+	case "throttled":
+		hints = append(hints, deciderHints["throttling"])
+	}
+
+	for _, name := range blockingDeciders(explain) {
+		if hint, ok := deciderHints[name]; ok && !containsString(hints, hint) {
+			hints = append(hints, hint)
+		}
+	}
+	return hints
+}
+
+// blockingDeciders returns the deciders that said NO or THROTTLE, ordered by the number of nodes they block
+func blockingDeciders(explain *models.AllocationExplain) []string {
+	names := make([]string, 0, len(explain.BlockingDeciderNodes))
+	for name := range explain.BlockingDeciderNodes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := explain.BlockingDeciderNodes[names[i]], explain.BlockingDeciderNodes[names[j]]
+		if a != b {
+			return a > b
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// summarizeAllocation condenses an explanation into one line for the check report
+func summarizeAllocation(explain *models.AllocationExplain) string {
+	var parts []string
+	if explain.UnassignedReason != "" {
+		parts = append(parts, explain.UnassignedReason)
+	}
+	if names := blockingDeciders(explain); len(names) > 0 {
+		blocked := make([]string, 0, len(names))
+		for _, name := range names {
+			count := explain.BlockingDeciderNodes[name]
+			unit := "nodes"
+			if count == 1 {
+				unit = "node"
+			}
+			blocked = append(blocked, fmt.Sprintf("%s (%d %s)", name, count, unit))
+		}
+		parts = append(parts, "blocked by "+strings.Join(blocked, ", "))
+	} else if explain.CanAllocate != "" {
+		parts = append(parts, explain.CanAllocate)
+	}
+	return strings.Join(parts, " - ")
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
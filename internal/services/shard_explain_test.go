@@ -0,0 +1,48 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const unassignedReplicaExplain = `{
+  "index": "logs", "shard": 0, "primary": false, "current_state": "unassigned",
+  "unassigned_info": {"reason": "NODE_LEFT", "at": "2025-01-01T10:00:00.000Z", "last_allocation_status": "no_attempt"},
+  "can_allocate": "no",
+  "allocate_explanation": "cannot allocate because allocation is not permitted to any of the nodes",
+  "node_allocation_decisions": [
+    {"node_name": "node-1", "node_decision": "no", "deciders": [
+      {"decider": "same_shard", "decision": "NO", "explanation": "a copy of this shard is already allocated to this node"},
+      {"decider": "disk_threshold", "decision": "NO", "explanation": "the node is above the high watermark"}]},
+    {"node_name": "node-2", "node_decision": "no", "deciders": [
+      {"decider": "same_shard", "decision": "NO", "explanation": "a copy of this shard is already allocated to this node"},
+      {"decider": "filter", "decision": "YES", "explanation": "node passes include/exclude/require filters"}]}
+  ]
+}`
+
+func TestParseAllocationExplain(t *testing.T) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(unassignedReplicaExplain), &data); err != nil {
+		t.Fatal(err)
+	}
+	explain := parseAllocationExplain(data)
+
+	if explain.Index != "logs" || explain.Shard != 0 || explain.Primary || explain.UnassignedReason != "NODE_LEFT" {
+		t.Fatalf("header: %+v", explain)
+	}
+	if len(explain.NodeDecisions) != 2 || len(explain.NodeDecisions[1].Deciders) != 1 {
+		t.Fatalf("YES deciders should be dropped: %+v", explain.NodeDecisions)
+	}
+	if explain.BlockingDeciderNodes["same_shard"] != 2 || explain.BlockingDeciderNodes["disk_threshold"] != 1 {
+		t.Fatalf("blocking deciders: %v", explain.BlockingDeciderNodes)
+	}
+	if len(explain.Hints) != 2 || !strings.Contains(explain.Hints[0], "number_of_replicas") {
+		t.Fatalf("hints should start with the most blocking decider: %v", explain.Hints)
+	}
+
+	want := "NODE_LEFT - blocked by same_shard (2 nodes), disk_threshold (1 node)"
+	if got := summarizeAllocation(explain); got != want {
+		t.Fatalf("summary %q, want %q", got, want)
+	}
+}
@@ -13,6 +13,7 @@ type ShardService interface {
 	GetAllShardInfos(ctx context.Context) ([]models.ShardInfo, error)
 	GetShardDistribution(ctx context.Context) (*models.ShardDistribution, error)
 	GetShardWarnings(ctx context.Context) (*models.ShardWarnings, error)
+	ExplainAllocation(ctx context.Context, req *models.AllocationExplainRequest) (*models.AllocationExplain, error)
 }
 
 type shardService struct {
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/interfaces"
+	"github.com/mertbahardogan/escope/internal/models"
+)
+
+// keyThreadPools are listed by default; any other pool shows up once it queues or rejects
+var keyThreadPools = map[string]bool{
+	"write":            true,
+	"bulk":             true,
+	"index":            true,
+	"search":           true,
+	"search_worker":    true,
+	"search_throttled": true,
+	"get":              true,
+	"refresh":          true,
+	"flush":            true,
+	"force_merge":      true,
+	"management":       true,
+	"snapshot":         true,
+}
+
+type ThreadPoolService interface {
+	GetThreadPoolSnapshot(ctx context.Context) (*models.ThreadPoolSnapshot, error)
+	CompareThreadPools(prev, current *models.ThreadPoolSnapshot) []models.ThreadPoolStats
+}
+
+type threadPoolService struct {
+	client interfaces.ElasticClient
+}
+
+func NewThreadPoolService(client interfaces.ElasticClient) ThreadPoolService {
+	return &threadPoolService{
+		client: client,
+	}
+}
+
+// GetThreadPoolSnapshot reads every thread pool of every node from nodes stats, sorted by
+// node and pool. Rates are -1 until the snapshot is compared with an earlier one.
+func (s *threadPoolService) GetThreadPoolSnapshot(ctx context.Context) (*models.ThreadPoolSnapshot, error) {
+	statsData, err := s.client.GetNodesStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrFailedToGetNodeStats, err)
+	}
+	return parseThreadPoolSnapshot(statsData, time.Now()), nil
+}
+
+func parseThreadPoolSnapshot(statsData map[string]interface{}, now time.Time) *models.ThreadPoolSnapshot {
+	snapshot := &models.ThreadPoolSnapshot{Timestamp: now}
+
+	nodes, _ := statsData[constants.NodesField].(map[string]interface{})
+	for nodeID, nodeData := range nodes {
+		node, ok := nodeData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := node[constants.NameField].(string)
+		if name == "" {
+			name = nodeID
+		}
+		pools, _ := node[constants.ThreadPoolField].(map[string]interface{})
+		for poolName, poolData := range pools {
+			pool, ok := poolData.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			counter := func(field string) int64 {
+				if v, ok := pool[field].(float64); ok {
+					return int64(v)
+				}
+				return 0
+			}
+			snapshot.Pools = append(snapshot.Pools, models.ThreadPoolStats{
+				Node:          name,
+				Pool:          poolName,
+				Threads:       counter(constants.ThreadsField),
+				Active:        counter(constants.ActiveField),
+				Queue:         counter(constants.QueueField),
+				Largest:       counter(constants.LargestField),
+				Rejected:      counter(constants.RejectedField),
+				Completed:     counter(constants.CompletedField),
+				RejectedRate:  -1,
+				CompletedRate: -1,
+			})
+		}
+	}
+
+	sort.Slice(snapshot.Pools, func(i, j int) bool {
+		if snapshot.Pools[i].Node != snapshot.Pools[j].Node {
+			return snapshot.Pools[i].Node < snapshot.Pools[j].Node
+		}
+		return snapshot.Pools[i].Pool < snapshot.Pools[j].Pool
+	})
+	return snapshot
+}
+
+// CompareThreadPools returns the pools of current with the rejections and completions since
+// prev. A counter that went backwards means the node restarted, and that pool gets no rate.
+func (s *threadPoolService) CompareThreadPools(prev, current *models.ThreadPoolSnapshot) []models.ThreadPoolStats {
+	return compareThreadPools(prev, current)
+}
+
+func compareThreadPools(prev, current *models.ThreadPoolSnapshot) []models.ThreadPoolStats {
+	pools := append([]models.ThreadPoolStats(nil), current.Pools...)
+	if prev == nil {
+		return pools
+	}
+	elapsed := current.Timestamp.Sub(prev.Timestamp).Seconds()
+	if elapsed <= 0 {
+		return pools
+	}
+
+	before := make(map[string]models.ThreadPoolStats, len(prev.Pools))
+	for _, p := range prev.Pools {
+		before[p.Node+"/"+p.Pool] = p
+	}
+	for i := range pools {
+		p, ok := before[pools[i].Node+"/"+pools[i].Pool]
+		if !ok || pools[i].Rejected < p.Rejected || pools[i].Completed < p.Completed {
+			continue
+		}
+		pools[i].RejectedDelta = pools[i].Rejected - p.Rejected
+		pools[i].RejectedRate = float64(pools[i].RejectedDelta) / elapsed
+		pools[i].CompletedRate = float64(pools[i].Completed-p.Completed) / elapsed
+	}
+	return pools
+}
+
+// IsKeyThreadPool reports whether a pool is listed without --all even when it is idle
+func IsKeyThreadPool(pool models.ThreadPoolStats) bool {
+	return keyThreadPools[pool.Pool] || pool.Queue > 0 || pool.Rejected > 0
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func threadPoolStats(t *testing.T, rejected, completed int) map[string]interface{} {
+	t.Helper()
+	raw := `{"nodes": {"n1": {"name": "data-1", "thread_pool": {
+	  "write":  {"threads": 8, "queue": 120, "active": 8, "rejected": ` + strconv.Itoa(rejected) + `, "largest": 8, "completed": ` + strconv.Itoa(completed) + `},
+	  "search": {"threads": 13, "queue": 0, "active": 1, "rejected": 0, "largest": 13, "completed": 500}}}}}`
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestCompareThreadPoolsComputesRejectionRates(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	prev := parseThreadPoolSnapshot(threadPoolStats(t, 100, 1000), start)
+	current := parseThreadPoolSnapshot(threadPoolStats(t, 140, 1200), start.Add(10*time.Second))
+
+	if len(current.Pools) != 2 || current.Pools[0].Pool != "search" || current.Pools[1].Queue != 120 {
+		t.Fatalf("pools: %+v", current.Pools)
+	}
+	if current.Pools[1].RejectedRate != -1 {
+		t.Fatalf("a single snapshot has no rate: %+v", current.Pools[1])
+	}
+
+	pools := compareThreadPools(prev, current)
+	write := pools[1]
+	if write.RejectedDelta != 40 || write.RejectedRate != 4 || write.CompletedRate != 20 {
+		t.Fatalf("write: %+v", write)
+	}
+	if pools[0].RejectedDelta != 0 || pools[0].RejectedRate != 0 {
+		t.Fatalf("search: %+v", pools[0])
+	}
+
+	// A restarted node resets its counters and gets no rate
+	restarted := parseThreadPoolSnapshot(threadPoolStats(t, 5, 10), start.Add(20*time.Second))
+	if p := compareThreadPools(current, restarted)[1]; p.RejectedDelta != 0 || p.RejectedRate != -1 {
+		t.Fatalf("restarted: %+v", p)
+	}
+}
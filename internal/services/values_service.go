@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/interfaces"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+const (
+	valuesAgg = "values"
+	boundsAgg = "bounds"
+)
+
+var (
+	numericTypes = map[string]bool{
+		"long": true, "integer": true, "short": true, "byte": true, "unsigned_long": true,
+		"double": true, "float": true, "half_float": true, "scaled_float": true,
+	}
+	dateTypes = map[string]bool{"date": true, "date_nanos": true}
+
+	// calendarInterval matches the intervals date_histogram only accepts as calendar_interval
+	calendarInterval = regexp.MustCompile(`^(1[mhdwMqy]|minute|hour|day|week|month|quarter|year)$`)
+
+	// dateIntervals are the fixed intervals picked for a date histogram, shortest first;
+	// longer spans fall back to calendar months and years
+	dateIntervals = []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"1s", time.Second}, {"5s", 5 * time.Second}, {"15s", 15 * time.Second}, {"30s", 30 * time.Second},
+		{"1m", time.Minute}, {"5m", 5 * time.Minute}, {"15m", 15 * time.Minute}, {"30m", 30 * time.Minute},
+		{"1h", time.Hour}, {"3h", 3 * time.Hour}, {"6h", 6 * time.Hour}, {"12h", 12 * time.Hour},
+		{"1d", 24 * time.Hour}, {"7d", 7 * 24 * time.Hour},
+	}
+)
+
+type ValuesService interface {
+	GetFieldValues(ctx context.Context, indexName, field string, opts models.ValuesOptions) (*models.FieldValues, error)
+}
+
+type valuesService struct {
+	client       interfaces.ElasticClient
+	indexService IndexService
+}
+
+func NewValuesService(client interfaces.ElasticClient) ValuesService {
+	return &valuesService{
+		client:       client,
+		indexService: NewIndexService(client),
+	}
+}
+
+// GetFieldValues aggregates a field according to its mapped type: top terms for keywords and
+// other exact values, a histogram for numbers and a date histogram for dates. Without an
+// interval the bounds of the matching values are read first to aim at opts.Buckets buckets.
+func (s *valuesService) GetFieldValues(ctx context.Context, indexName, field string, opts models.ValuesOptions) (*models.FieldValues, error) {
+	mapping, err := s.indexService.GetIndexFields(ctx, indexName)
+	if err != nil {
+		return nil, err
+	}
+	fieldType := ""
+	for _, f := range mapping {
+		if f.Path == field {
+			fieldType = f.Type
+			break
+		}
+	}
+	if fieldType == "" {
+		return nil, fmt.Errorf(constants.ErrFieldNotInMapping, field, indexName)
+	}
+
+	values := &models.FieldValues{Index: indexName, Field: field, Type: fieldType, Kind: valuesKind(fieldType)}
+	if values.Kind == "" {
+		return nil, fmt.Errorf(constants.ErrFieldNotAggregatable, field, fieldType)
+	}
+
+	query, err := buildValuesQuery(opts.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	values.Interval = opts.Interval
+	if values.Kind != models.ValuesKindTerms && values.Interval == "" {
+		body, err := buildValuesSearchBody(field, opts.Nested, query, boundsAgg, map[string]interface{}{"stats": map[string]interface{}{"field": field}})
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.client.SearchWithBody(ctx, indexName, body)
+		if err != nil {
+			return nil, fmt.Errorf(constants.ErrFieldValuesFailed, err)
+		}
+		bounds, _ := valuesAggregation(resp, opts.Nested, boundsAgg)
+		lo, _ := bounds["min"].(float64)
+		hi, _ := bounds["max"].(float64)
+		if values.Kind == models.ValuesKindDateHistogram {
+			values.Interval = pickDateInterval(time.Duration(hi-lo)*time.Millisecond, opts.Buckets)
+		} else {
+			values.Interval = strconv.FormatFloat(pickNumericInterval(hi-lo, opts.Buckets), 'f', -1, 64)
+		}
+	}
+
+	agg, err := buildValuesAgg(field, values.Kind, values.Interval, opts.Top)
+	if err != nil {
+		return nil, err
+	}
+	body, err := buildValuesSearchBody(field, opts.Nested, query, valuesAgg, agg)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.SearchWithBody(ctx, indexName, body)
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrFieldValuesFailed, err)
+	}
+	parseFieldValues(resp, opts.Nested, values)
+	return values, nil
+}
+
+func valuesKind(fieldType string) string {
+	switch {
+	case dateTypes[fieldType]:
+		return models.ValuesKindDateHistogram
+	case numericTypes[fieldType]:
+		return models.ValuesKindHistogram
+	case aggregatableTypes[fieldType]:
+		return models.ValuesKindTerms
+	}
+	return ""
+}
+
+// buildValuesQuery turns --query into a query object: JSON when it starts with "{", Lucene
+// query_string syntax otherwise. Empty means every document.
+func buildValuesQuery(query string) (map[string]interface{}, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil
+	}
+	if strings.HasPrefix(query, "{") {
+		var parsed map[string]interface{}
+		if err := json.Unmarshal([]byte(query), &parsed); err != nil {
+			return nil, fmt.Errorf(constants.ErrInvalidQueryJSON, err)
+		}
+		if inner, ok := parsed["query"].(map[string]interface{}); ok && len(parsed) == 1 {
+			return inner, nil
+		}
+		return parsed, nil
+	}
+	return map[string]interface{}{"query_string": map[string]interface{}{"query": query}}, nil
+}
+
+func buildValuesAgg(field, kind, interval string, top int) (map[string]interface{}, error) {
+	switch kind {
+	case models.ValuesKindTerms:
+		return map[string]interface{}{"terms": map[string]interface{}{"field": field, "size": top}}, nil
+	case models.ValuesKindHistogram:
+		step, err := strconv.ParseFloat(interval, 64)
+		if err != nil || step <= 0 {
+			return nil, fmt.Errorf(constants.ErrInvalidHistogramInterval, interval)
+		}
+		return map[string]interface{}{"histogram": map[string]interface{}{"field": field, "interval": step, "min_doc_count": 0}}, nil
+	}
+
+	histogram := map[string]interface{}{"field": field, "min_doc_count": 0, "format": dateKeyFormat(interval)}
+	if calendarInterval.MatchString(interval) {
+		histogram["calendar_interval"] = interval
+	} else {
+		histogram["fixed_interval"] = interval
+	}
+	return map[string]interface{}{"date_histogram": histogram}, nil
+}
+
+// buildValuesSearchBody wraps a single aggregation named name, inside a nested aggregation
+// when the field lives in a nested object, like buildFieldCardinalitySearchBody
+func buildValuesSearchBody(field string, nested bool, query map[string]interface{}, name string, agg map[string]interface{}) ([]byte, error) {
+	aggs := map[string]interface{}{name: agg}
+	if nested {
+		path, err := nestedPathFromField(field)
+		if err != nil {
+			return nil, err
+		}
+		aggs = map[string]interface{}{
+			"nested_scope": map[string]interface{}{
+				"nested": map[string]interface{}{"path": path},
+				"aggs":   aggs,
+			},
+		}
+	}
+
+	body := map[string]interface{}{
+		"size":             0,
+		"track_total_hits": true,
+		"aggs":             aggs,
+	}
+	if query != nil {
+		body["query"] = query
+	}
+	return json.Marshal(body)
+}
+
+// valuesAggregation returns the named aggregation and the number of documents it ran over
+func valuesAggregation(resp map[string]interface{}, nested bool, name string) (map[string]interface{}, int64) {
+	var total int64
+	if hits, ok := resp["hits"].(map[string]interface{}); ok {
+		if t, ok := hits["total"].(map[string]interface{}); ok {
+			total = int64Field(t, "value")
+		}
+	}
+	aggs, _ := resp["aggregations"].(map[string]interface{})
+	if nested {
+		scope, _ := aggs["nested_scope"].(map[string]interface{})
+		total = int64Field(scope, "doc_count")
+		aggs = scope
+	}
+	agg, _ := aggs[name].(map[string]interface{})
+	return agg, total
+}
+
+func parseFieldValues(resp map[string]interface{}, nested bool, values *models.FieldValues) {
+	agg, total := valuesAggregation(resp, nested, valuesAgg)
+	values.Total = total
+	values.Other = int64Field(agg, "sum_other_doc_count")
+	values.Buckets = make([]models.ValueBucket, 0)
+
+	buckets, _ := agg["buckets"].([]interface{})
+	for _, b := range buckets {
+		bucket, ok := b.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key := util.GetStringField(bucket, "key_as_string")
+		if key == "" {
+			switch k := bucket["key"].(type) {
+			case float64:
+				key = strconv.FormatFloat(k, 'f', -1, 64)
+			default:
+				key = fmt.Sprintf("%v", k)
+			}
+		}
+		vb := models.ValueBucket{Key: key, Count: int64Field(bucket, "doc_count")}
+		if total > 0 {
+			vb.Percent = float64(vb.Count) / float64(total) * constants.HundredMultiplier
+		}
+		values.Buckets = append(values.Buckets, vb)
+	}
+}
+
+// pickDateInterval returns the shortest interval that splits span into at most buckets buckets
+func pickDateInterval(span time.Duration, buckets int) string {
+	if buckets < 1 {
+		buckets = 1
+	}
+	target := span / time.Duration(buckets)
+	for _, interval := range dateIntervals {
+		if interval.duration >= target {
+			return interval.name
+		}
+	}
+	if target <= 31*24*time.Hour {
+		return "1M"
+	}
+	return "1y"
+}
+
+// pickNumericInterval rounds span/buckets up to 1, 2 or 5 times a power of ten
+func pickNumericInterval(span float64, buckets int) float64 {
+	if buckets < 1 {
+		buckets = 1
+	}
+	raw := span / float64(buckets)
+	if raw <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, step := range []float64{1, 2, 5, 10} {
+		if step*magnitude >= raw {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// dateKeyFormat keeps date histogram labels as short as the interval allows
+func dateKeyFormat(interval string) string {
+	switch {
+	case interval == "1y" || interval == "year":
+		return "yyyy"
+	case interval == "1M" || interval == "month" || interval == "1q" || interval == "quarter":
+		return "yyyy-MM"
+	case strings.HasSuffix(interval, "d") || interval == "1w" || interval == "day" || interval == "week":
+		return "yyyy-MM-dd"
+	}
+	return "yyyy-MM-dd HH:mm:ss"
+}
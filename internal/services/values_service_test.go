@@ -0,0 +1,69 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mertbahardogan/escope/internal/models"
+)
+
+func TestPickValuesIntervals(t *testing.T) {
+	if got := pickDateInterval(24*time.Hour, 20); got != "3h" {
+		t.Fatalf("day over 20 buckets: %s", got)
+	}
+	if got := pickDateInterval(365*24*time.Hour, 20); got != "1M" {
+		t.Fatalf("year over 20 buckets: %s", got)
+	}
+	if got := pickNumericInterval(1000, 20); got != 50 {
+		t.Fatalf("0..1000 over 20 buckets: %v", got)
+	}
+	if got := pickNumericInterval(0, 20); got != 1 {
+		t.Fatalf("single value: %v", got)
+	}
+}
+
+func TestBuildValuesSearchBody(t *testing.T) {
+	query, err := buildValuesQuery(`status:500 AND host:web-1`)
+	if err != nil || query["query_string"] == nil {
+		t.Fatalf("query_string: %v %v", query, err)
+	}
+	query, err = buildValuesQuery(`{"query": {"term": {"status": 500}}}`)
+	if err != nil || query["term"] == nil {
+		t.Fatalf("json query: %v %v", query, err)
+	}
+	if _, err := buildValuesQuery(`{"term":`); err == nil {
+		t.Fatal("broken JSON must fail")
+	}
+
+	agg, err := buildValuesAgg("@timestamp", models.ValuesKindDateHistogram, "1M", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := buildValuesSearchBody("@timestamp", false, query, valuesAgg, agg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), `"calendar_interval":"1M"`) || !strings.Contains(string(body), `"term":{"status":500}`) {
+		t.Fatalf("body: %s", body)
+	}
+	if _, err := buildValuesAgg("price", models.ValuesKindHistogram, "1h", 0); err == nil {
+		t.Fatal("non-numeric interval on a number must fail")
+	}
+}
+
+func TestParseFieldValuesNested(t *testing.T) {
+	var resp map[string]interface{}
+	raw := `{"hits": {"total": {"value": 10}}, "aggregations": {"nested_scope": {"doc_count": 40,
+	  "values": {"sum_other_doc_count": 6, "buckets": [
+	    {"key": "alice", "doc_count": 30}, {"key": "bob", "doc_count": 4}]}}}}`
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatal(err)
+	}
+	values := &models.FieldValues{Kind: models.ValuesKindTerms}
+	parseFieldValues(resp, true, values)
+	if values.Total != 40 || values.Other != 6 || len(values.Buckets) != 2 || values.Buckets[0].Percent != 75 {
+		t.Fatalf("values: %+v", values)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/mertbahardogan/escope/internal/constants"
 	"github.com/mertbahardogan/escope/internal/models"
+	"strings"
+	"time"
 )
 
 type CheckFormatter struct{}
@@ -12,24 +14,28 @@ func NewCheckFormatter() *CheckFormatter {
 	return &CheckFormatter{}
 }
 
-func (f *CheckFormatter) FormatCheckReport(
-	clusterHealth *models.ClusterInfo,
-	nodeHealths []models.CheckNodeHealth,
-	shardHealth *models.ShardHealth,
-	shardWarnings *models.ShardWarnings,
-	indexHealths []models.IndexHealth,
-	resourceUsage *models.ResourceUsage,
-	performance *models.Performance,
-	nodeBreakdown *models.NodeBreakdown,
-	segmentWarnings *models.SegmentWarnings,
-	scaleWarnings *models.ScaleWarnings,
-	indicesWithoutAlias []string,
-) string {
+func (f *CheckFormatter) FormatCheckReport(report *models.CheckReport) string {
+	clusterHealth := report.ClusterHealth
+	nodeHealths := report.NodeHealths
+	shardHealth := report.ShardHealth
+	shardWarnings := report.ShardWarnings
+	indexHealths := report.IndexHealths
+	resourceUsage := report.ResourceUsage
+	performance := report.Performance
+	segmentWarnings := report.SegmentWarnings
+	scaleWarnings := report.ScaleWarnings
+	indicesWithoutAlias := report.IndicesWithoutAlias
+
 	title := "ESCOPE CLUSTER ANALYSIS"
 
 	var sections []ReportSection
 
-	criticalIssues := f.getCriticalIssues(clusterHealth, shardHealth, shardWarnings, resourceUsage)
+	summary := report.Summary
+	if summary == nil {
+		summary = f.Summarize(report)
+	}
+
+	criticalIssues := summary.CriticalIssues
 	if len(criticalIssues) > 0 {
 		sections = append(sections, ReportSection{
 			Title: "CRITICAL ISSUES: " + fmt.Sprintf("%d", len(criticalIssues)),
@@ -37,7 +43,7 @@ func (f *CheckFormatter) FormatCheckReport(
 		})
 	}
 
-	warningIssues := f.getWarningIssues(clusterHealth, shardHealth, shardWarnings, indexHealths, nodeHealths, resourceUsage, segmentWarnings, scaleWarnings)
+	warningIssues := summary.WarningIssues
 	if len(warningIssues) > 0 {
 		sections = append(sections, ReportSection{
 			Title: "WARNING ISSUES: " + fmt.Sprintf("%d", len(warningIssues)),
@@ -45,12 +51,30 @@ func (f *CheckFormatter) FormatCheckReport(
 		})
 	}
 
+	if shardWarnings != nil && len(shardWarnings.UnassignedReasons) > 0 {
+		var reasonItems []string
+		for _, r := range shardWarnings.UnassignedReasons {
+			copyType := constants.ReplicaString
+			if r.Primary {
+				copyType = constants.PrimaryString
+			}
+			reasonItems = append(reasonItems, fmt.Sprintf(constants.MsgUnassignedShardReason, r.Index, r.Shard, strings.ToLower(copyType), r.Reason))
+		}
+		sections = append(sections, ReportSection{
+			Title: "UNASSIGNED SHARDS",
+			Items: reasonItems,
+		})
+	}
+
 	// CPU Performance Metrics
 	var cpuItems []string
 	if resourceUsage != nil {
 		cpuItems = append(cpuItems, "Average CPU Usage: "+fmt.Sprintf("%.1f%%", resourceUsage.CPUUsage))
 		cpuItems = append(cpuItems, "CPU Usage Min: "+fmt.Sprintf("%.1f%% - %s", resourceUsage.CPUUsageMin, resourceUsage.CPUUsageMinNode))
 		cpuItems = append(cpuItems, "CPU Usage Max: "+fmt.Sprintf("%.1f%% - %s", resourceUsage.CPUUsageMax, resourceUsage.CPUUsageMaxNode))
+		if resourceUsage.CPUUsageMax >= constants.HighCPUThreshold && resourceUsage.CPUUsageMaxNode != "" {
+			cpuItems = append(cpuItems, fmt.Sprintf(constants.MsgHotThreadsHint, resourceUsage.CPUUsageMaxNode))
+		}
 	}
 	if len(cpuItems) > 0 {
 		sections = append(sections, ReportSection{
@@ -73,6 +97,13 @@ func (f *CheckFormatter) FormatCheckReport(
 		})
 	}
 
+	if resourceUsage != nil && resourceUsage.DiskWatermarks != nil && len(resourceUsage.NodeDisks) > 0 {
+		sections = append(sections, ReportSection{
+			Title: "DISK WATERMARKS",
+			Items: f.formatDiskWatermarks(resourceUsage),
+		})
+	}
+
 	// General Performance Metrics (Index/Query times)
 	var performanceItems []string
 	if performance != nil && performance.IndexTotal > 0 {
@@ -141,38 +172,100 @@ func (f *CheckFormatter) FormatCheckReport(
 	}
 
 	formatter := NewGenericTableFormatter()
-	return formatter.FormatReport(title, sections)
+	return formatter.FormatReport(title, sections) + f.FormatSummaryLine(summary) + "\n"
+}
+
+// Summarize classifies every finding of the report by severity
+func (f *CheckFormatter) Summarize(report *models.CheckReport) *models.CheckSummary {
+	summary := &models.CheckSummary{
+		CriticalIssues: f.getCriticalIssues(report.ClusterHealth, report.ShardHealth, report.ShardWarnings, report.ResourceUsage),
+		WarningIssues: f.getWarningIssues(report.ClusterHealth, report.ShardHealth, report.ShardWarnings, report.IndexHealths,
+			report.NodeHealths, report.ResourceUsage, report.SegmentWarnings, report.ScaleWarnings),
+	}
+	if report.ThreadPoolWarnings != nil {
+		summary.WarningIssues = append(summary.WarningIssues, report.ThreadPoolWarnings.WarningIssues...)
+	}
+	if report.BreakerWarnings != nil {
+		summary.CriticalIssues = append(summary.CriticalIssues, report.BreakerWarnings.CriticalIssues...)
+		summary.WarningIssues = append(summary.WarningIssues, report.BreakerWarnings.WarningIssues...)
+	}
+	for _, finding := range report.RuleFindings {
+		issue := fmt.Sprintf("[%s] %s", finding.Rule, finding.Message)
+		if finding.Severity == constants.SeverityCritical {
+			summary.CriticalIssues = append(summary.CriticalIssues, issue)
+		} else {
+			summary.WarningIssues = append(summary.WarningIssues, issue)
+		}
+	}
+	if summary.CriticalIssues == nil {
+		summary.CriticalIssues = []string{}
+	}
+	if summary.WarningIssues == nil {
+		summary.WarningIssues = []string{}
+	}
+	summary.Critical = len(summary.CriticalIssues)
+	summary.Warning = len(summary.WarningIssues)
+
+	switch {
+	case summary.Critical > 0:
+		summary.Status = constants.SeverityCritical
+	case summary.Warning > 0:
+		summary.Status = constants.SeverityWarning
+	default:
+		summary.Status = constants.SeverityOK
+	}
+	return summary
+}
+
+// FormatSummaryLine renders the one-line severity digest printed under the report
+func (f *CheckFormatter) FormatSummaryLine(summary *models.CheckSummary) string {
+	return fmt.Sprintf("Check result: %s (critical: %d, warning: %d)", summary.Status, summary.Critical, summary.Warning)
 }
 
 func (f *CheckFormatter) getCriticalIssues(clusterHealth *models.ClusterInfo, shardHealth *models.ShardHealth, shardWarnings *models.ShardWarnings, resourceUsage *models.ResourceUsage) []string {
 	var issues []string
 
-	if clusterHealth.UnassignedShards > 0 {
-		issues = append(issues, fmt.Sprintf("Unassigned Shards: %d", clusterHealth.UnassignedShards))
-	}
+	if clusterHealth == nil {
+		issues = append(issues, "Cluster health unavailable")
+	} else {
+		if clusterHealth.Status == constants.HealthRed {
+			issues = append(issues, "Cluster Status: RED")
+		}
 
-	if shardHealth.UnassignedShards > 0 {
-		issues = append(issues, fmt.Sprintf("Unassigned Shards: %d", shardHealth.UnassignedShards))
+		if clusterHealth.DelayedUnassignedShards > 0 {
+			issues = append(issues, fmt.Sprintf("Delayed Unassigned Shards: %d", clusterHealth.DelayedUnassignedShards))
+		}
 	}
 
-	if clusterHealth.DelayedUnassignedShards > 0 {
-		issues = append(issues, fmt.Sprintf("Delayed Unassigned Shards: %d", clusterHealth.DelayedUnassignedShards))
+	// Cluster health, the shard listing and the shard warnings all count unassigned shards;
+	// report the condition once, from the first source available
+	unassigned := 0
+	switch {
+	case clusterHealth != nil:
+		unassigned = clusterHealth.UnassignedShards
+	case shardHealth != nil:
+		unassigned = shardHealth.UnassignedShards
+	case shardWarnings != nil:
+		unassigned = shardWarnings.UnassignedShards
 	}
-
-	if resourceUsage != nil && resourceUsage.DiskTotal > 0 {
-		diskUsed := resourceUsage.DiskTotal - resourceUsage.DiskAvailable
-		diskPercent := float64(diskUsed) * 100 / float64(resourceUsage.DiskTotal)
-		if diskPercent > 85 {
-			issues = append(issues, fmt.Sprintf("High Disk Usage: %.1f%%", diskPercent))
-		}
+	if unassigned > 0 {
+		issues = append(issues, fmt.Sprintf("Unassigned Shards: %d", unassigned))
 	}
 
-	if clusterHealth.TimedOut {
+	diskCritical, _ := f.diskIssues(resourceUsage)
+	issues = append(issues, diskCritical...)
+
+	if clusterHealth != nil && clusterHealth.TimedOut {
 		issues = append(issues, "Cluster health check timed out")
 	}
 
 	if shardWarnings != nil {
-		issues = append(issues, shardWarnings.CriticalIssues...)
+		shardUnassigned := fmt.Sprintf(constants.MsgUnassignedShards, shardWarnings.UnassignedShards)
+		for _, issue := range shardWarnings.CriticalIssues {
+			if issue != shardUnassigned {
+				issues = append(issues, issue)
+			}
+		}
 	}
 
 	return issues
@@ -181,32 +274,40 @@ func (f *CheckFormatter) getCriticalIssues(clusterHealth *models.ClusterInfo, sh
 func (f *CheckFormatter) getWarningIssues(clusterHealth *models.ClusterInfo, shardHealth *models.ShardHealth, shardWarnings *models.ShardWarnings, indexHealths []models.IndexHealth, nodeHealths []models.CheckNodeHealth, resourceUsage *models.ResourceUsage, segmentWarnings *models.SegmentWarnings, scaleWarnings *models.ScaleWarnings) []string {
 	var issues []string
 
-	if clusterHealth.RelocatingShards > 0 {
-		issues = append(issues, fmt.Sprintf("Relocating Shards: %d", clusterHealth.RelocatingShards))
-	}
+	if clusterHealth != nil {
+		if clusterHealth.Status == constants.HealthYellow {
+			issues = append(issues, "Cluster Status: YELLOW")
+		}
 
-	if clusterHealth.InitializingShards > 0 {
-		issues = append(issues, fmt.Sprintf("Initializing Shards: %d", clusterHealth.InitializingShards))
+		if clusterHealth.RelocatingShards > 0 {
+			issues = append(issues, fmt.Sprintf("Relocating Shards: %d", clusterHealth.RelocatingShards))
+		}
+
+		if clusterHealth.InitializingShards > 0 {
+			issues = append(issues, fmt.Sprintf("Initializing Shards: %d", clusterHealth.InitializingShards))
+		}
 	}
 
-	if shardHealth.RelocatingShards > 0 {
+	if shardHealth != nil && shardHealth.RelocatingShards > 0 {
 		issues = append(issues, fmt.Sprintf("Relocating Shards: %d", shardHealth.RelocatingShards))
 	}
 
-	if clusterHealth.NumberOfPendingTasks > 0 {
-		issues = append(issues, fmt.Sprintf("Pending Tasks: %d", clusterHealth.NumberOfPendingTasks))
-	}
+	if clusterHealth != nil {
+		if clusterHealth.NumberOfPendingTasks > 0 {
+			issues = append(issues, fmt.Sprintf("Pending Tasks: %d", clusterHealth.NumberOfPendingTasks))
+		}
 
-	if clusterHealth.NumberOfInFlightFetch > 0 {
-		issues = append(issues, fmt.Sprintf("In Flight Fetch: %d", clusterHealth.NumberOfInFlightFetch))
-	}
+		if clusterHealth.NumberOfInFlightFetch > 0 {
+			issues = append(issues, fmt.Sprintf("In Flight Fetch: %d", clusterHealth.NumberOfInFlightFetch))
+		}
 
-	if clusterHealth.TaskMaxWaitingInQueueMillis > 1000 {
-		issues = append(issues, fmt.Sprintf("Task Max Waiting: %dms", clusterHealth.TaskMaxWaitingInQueueMillis))
-	}
+		if clusterHealth.TaskMaxWaitingInQueueMillis > 1000 {
+			issues = append(issues, fmt.Sprintf("Task Max Waiting: %dms", clusterHealth.TaskMaxWaitingInQueueMillis))
+		}
 
-	if clusterHealth.ActiveShardsPercentAsNumber < 100.0 {
-		issues = append(issues, fmt.Sprintf("Active Shards Percent: %.1f%%", clusterHealth.ActiveShardsPercentAsNumber))
+		if clusterHealth.ActiveShardsPercentAsNumber < 100.0 {
+			issues = append(issues, fmt.Sprintf("Active Shards Percent: %.1f%%", clusterHealth.ActiveShardsPercentAsNumber))
+		}
 	}
 
 	yellowIndices := 0
@@ -229,6 +330,9 @@ func (f *CheckFormatter) getWarningIssues(clusterHealth *models.ClusterInfo, sha
 		issues = append(issues, fmt.Sprintf("High Heap Usage: %.1f%%", resourceUsage.HeapUsage))
 	}
 
+	_, diskWarnings := f.diskIssues(resourceUsage)
+	issues = append(issues, diskWarnings...)
+
 	if shardWarnings != nil {
 		issues = append(issues, shardWarnings.WarningIssues...)
 	}
@@ -256,10 +360,10 @@ func (f *CheckFormatter) getCategorizedRecommendations(clusterHealth *models.Clu
 	recommendations[constants.RecommendationCategoryNode] = []string{}
 	recommendations[constants.RecommendationCategoryGeneral] = []string{}
 
-	if clusterHealth.UnassignedShards > 0 {
+	if clusterHealth != nil && clusterHealth.UnassignedShards > 0 {
 		recommendations[constants.RecommendationCategoryShard] = append(recommendations[constants.RecommendationCategoryShard], fmt.Sprintf("Investigate unassigned shards (%d) - check cluster allocation settings", clusterHealth.UnassignedShards))
 	}
-	if clusterHealth.RelocatingShards > 0 {
+	if clusterHealth != nil && clusterHealth.RelocatingShards > 0 {
 		recommendations[constants.RecommendationCategoryShard] = append(recommendations[constants.RecommendationCategoryShard], fmt.Sprintf("Monitor shard relocation progress (%d) - ensure completion", clusterHealth.RelocatingShards))
 	}
 
@@ -272,12 +376,8 @@ func (f *CheckFormatter) getCategorizedRecommendations(clusterHealth *models.Clu
 	if yellowIndices > 0 {
 		recommendations[constants.RecommendationCategoryIndex] = append(recommendations[constants.RecommendationCategoryIndex], fmt.Sprintf("Review yellow indices (%d) - check replica settings and node availability", yellowIndices))
 	}
-	if resourceUsage != nil && resourceUsage.DiskTotal > 0 {
-		diskUsed := resourceUsage.DiskTotal - resourceUsage.DiskAvailable
-		diskPercent := float64(diskUsed) * 100 / float64(resourceUsage.DiskTotal)
-		if diskPercent > 85 {
-			recommendations[constants.RecommendationCategoryIndex] = append(recommendations[constants.RecommendationCategoryIndex], "Consider index lifecycle management for disk usage optimization")
-		}
+	if diskCritical, diskWarnings := f.diskIssues(resourceUsage); len(diskCritical)+len(diskWarnings) > 0 {
+		recommendations[constants.RecommendationCategoryIndex] = append(recommendations[constants.RecommendationCategoryIndex], "Consider index lifecycle management for disk usage optimization")
 	}
 
 	if segmentWarnings != nil {
@@ -313,6 +413,67 @@ func (f *CheckFormatter) getCategorizedRecommendations(clusterHealth *models.Clu
 	return recommendations
 }
 
+// diskIssues measures each data node against the cluster's disk watermarks: nodes at or
+// close to flood stage are critical, nodes past the low watermark a warning. Reports without
+// per-node disks fall back to the aggregate usage.
+func (f *CheckFormatter) diskIssues(resourceUsage *models.ResourceUsage) (critical, warning []string) {
+	if resourceUsage == nil {
+		return nil, nil
+	}
+	if len(resourceUsage.NodeDisks) == 0 {
+		if resourceUsage.DiskTotal > 0 {
+			diskUsed := resourceUsage.DiskTotal - resourceUsage.DiskAvailable
+			diskPercent := float64(diskUsed) * 100 / float64(resourceUsage.DiskTotal)
+			if diskPercent > 85 {
+				critical = append(critical, fmt.Sprintf("High Disk Usage: %.1f%%", diskPercent))
+			}
+		}
+		return critical, warning
+	}
+
+	for _, disk := range resourceUsage.NodeDisks {
+		switch {
+		case disk.Status.Level == models.DiskLevelFloodStage:
+			critical = append(critical, fmt.Sprintf("Disk Flood Stage: %s (indices read-only)", disk.Node))
+		case disk.Status.Level == models.DiskLevelHigh:
+			critical = append(critical, fmt.Sprintf("Disk Above High Watermark: %s (%s left before flood stage)", disk.Node, models.FormatBytes(disk.Status.FloodHeadroomBytes)))
+		case disk.Status.NearFloodStage:
+			critical = append(critical, fmt.Sprintf("Disk Near Flood Stage: %s (%s left)", disk.Node, models.FormatBytes(disk.Status.FloodHeadroomBytes)))
+		case disk.Status.Level == models.DiskLevelLow:
+			warning = append(warning, fmt.Sprintf("Disk Above Low Watermark: %s (no new shards allocated)", disk.Node))
+		}
+	}
+	return critical, warning
+}
+
+// formatDiskWatermarks lists the watermarks and every data node that has crossed one or is
+// close to flood stage
+func (f *CheckFormatter) formatDiskWatermarks(resourceUsage *models.ResourceUsage) []string {
+	items := []string{"Watermarks: " + resourceUsage.DiskWatermarks.Summary()}
+	flagged := 0
+	for _, disk := range resourceUsage.NodeDisks {
+		if disk.Status.Level == models.DiskLevelOK && !disk.Status.NearFloodStage {
+			continue
+		}
+		flagged++
+		used := float64(disk.TotalBytes-disk.AvailableBytes) * 100 / float64(disk.TotalBytes)
+		items = append(items, fmt.Sprintf("%s: %.1f%% used, %s to high, %s to flood stage (%s)", disk.Node, used,
+			formatSignedBytes(disk.Status.HighHeadroomBytes), formatSignedBytes(disk.Status.FloodHeadroomBytes), disk.Status.Level))
+	}
+	if flagged == 0 {
+		items = append(items, fmt.Sprintf("All %d data nodes are below the low watermark", len(resourceUsage.NodeDisks)))
+	}
+	return items
+}
+
+// formatSignedBytes keeps the sign of a headroom that has gone negative
+func formatSignedBytes(bytes int64) string {
+	if bytes < 0 {
+		return "-" + models.FormatBytes(-bytes)
+	}
+	return models.FormatBytes(bytes)
+}
+
 func (f *CheckFormatter) formatScaleAnalysis(scaleWarnings *models.ScaleWarnings) []string {
 	var items []string
 
@@ -401,3 +562,119 @@ func (f *CheckFormatter) formatNumber(n int64) string {
 	}
 	return fmt.Sprintf("%d", n)
 }
+
+// FormatTrendReport renders the analysis of a continuous check: issues with their sample window,
+// min/avg/max/p95 of the sampled metrics and the recommendations
+func (f *CheckFormatter) FormatTrendReport(result *models.MonitoringResult) string {
+	title := "ESCOPE MONITORING TREND REPORT"
+
+	summary := result.Summary
+	if summary == nil {
+		summary = f.SummarizeTrend(result)
+	}
+
+	var sections []ReportSection
+	sections = append(sections, ReportSection{
+		Title: "WINDOW",
+		Items: []string{
+			fmt.Sprintf("Samples: %d", result.SampleCount),
+			fmt.Sprintf("From: %s", f.formatTimestamp(result.StartedAt)),
+			fmt.Sprintf("To: %s", f.formatTimestamp(result.EndedAt)),
+		},
+	})
+
+	var criticalItems, warningItems []string
+	for _, issue := range result.Issues {
+		items := []string{
+			fmt.Sprintf("%s: %s", issue.Type, issue.Description),
+			fmt.Sprintf("  Occurrences: %d | First seen: %s | Last seen: %s",
+				issue.Occurrences, f.formatTimestamp(issue.FirstSeen), f.formatTimestamp(issue.LastSeen)),
+		}
+		if issue.Severity == constants.SeverityCritical {
+			criticalItems = append(criticalItems, items...)
+		} else {
+			warningItems = append(warningItems, items...)
+		}
+	}
+	if len(criticalItems) > 0 {
+		sections = append(sections, ReportSection{
+			Title: fmt.Sprintf("CRITICAL ISSUES: %d", summary.Critical),
+			Items: criticalItems,
+		})
+	}
+	if len(warningItems) > 0 {
+		sections = append(sections, ReportSection{
+			Title: fmt.Sprintf("WARNING ISSUES: %d", summary.Warning),
+			Items: warningItems,
+		})
+	}
+
+	if len(result.Recommendations) > 0 {
+		sections = append(sections, ReportSection{
+			Title: "RECOMMENDATIONS",
+			Items: result.Recommendations,
+		})
+	}
+
+	formatter := NewGenericTableFormatter()
+
+	headers := []string{"Metric", "Min", "Avg", "Max", "P95", "Samples"}
+	rows := [][]string{
+		f.metricRow("Heap %", result.Stats.HeapPercent, "%.1f%%"),
+		f.metricRow("CPU %", result.Stats.CPUPercent, "%.1f%%"),
+		f.metricRow("Disk %", result.Stats.DiskPercent, "%.1f%%"),
+		f.metricRow("Query Latency", result.Stats.QueryLatencyMs, "%.1fms"),
+		f.metricRow("Index Latency", result.Stats.IndexLatencyMs, "%.1fms"),
+	}
+
+	return formatter.FormatReport(title, sections) +
+		"\nTrend metrics (cluster average per sample, latencies per interval):\n" +
+		formatter.FormatTable(headers, rows) +
+		f.FormatSummaryLine(summary) + "\n"
+}
+
+// SummarizeTrend counts the trend issues per severity
+func (f *CheckFormatter) SummarizeTrend(result *models.MonitoringResult) *models.CheckSummary {
+	summary := &models.CheckSummary{
+		Status:         constants.SeverityOK,
+		CriticalIssues: []string{},
+		WarningIssues:  []string{},
+	}
+	for _, issue := range result.Issues {
+		text := fmt.Sprintf("%s: %s", issue.Type, issue.Description)
+		if issue.Severity == constants.SeverityCritical {
+			summary.CriticalIssues = append(summary.CriticalIssues, text)
+		} else {
+			summary.WarningIssues = append(summary.WarningIssues, text)
+		}
+	}
+	summary.Critical = len(summary.CriticalIssues)
+	summary.Warning = len(summary.WarningIssues)
+	if summary.Critical > 0 {
+		summary.Status = constants.SeverityCritical
+	} else if summary.Warning > 0 {
+		summary.Status = constants.SeverityWarning
+	}
+	return summary
+}
+
+func (f *CheckFormatter) metricRow(name string, m models.MetricSummary, format string) []string {
+	if m.Samples == 0 {
+		return []string{name, constants.DashString, constants.DashString, constants.DashString, constants.DashString, "0"}
+	}
+	return []string{
+		name,
+		fmt.Sprintf(format, m.Min),
+		fmt.Sprintf(format, m.Avg),
+		fmt.Sprintf(format, m.Max),
+		fmt.Sprintf(format, m.P95),
+		fmt.Sprintf("%d", m.Samples),
+	}
+}
+
+func (f *CheckFormatter) formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return constants.DashString
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/models"
+)
+
+func TestSummarizeCountsUnassignedShardsOnce(t *testing.T) {
+	report := &models.CheckReport{
+		ClusterHealth: &models.ClusterInfo{Status: constants.HealthGreen, UnassignedShards: 3, ActiveShardsPercentAsNumber: 100},
+		ShardHealth:   &models.ShardHealth{UnassignedShards: 3},
+		ShardWarnings: &models.ShardWarnings{
+			UnassignedShards: 3,
+			CriticalIssues:   []string{fmt.Sprintf(constants.MsgUnassignedShards, 3)},
+		},
+	}
+
+	summary := NewCheckFormatter().Summarize(report)
+	unassigned := 0
+	for _, issue := range summary.CriticalIssues {
+		if strings.Contains(strings.ToLower(issue), "unassigned shards") {
+			unassigned++
+		}
+	}
+	if unassigned != 1 || summary.Critical != 1 {
+		t.Fatalf("unassigned shards must be reported once: %v", summary.CriticalIssues)
+	}
+
+	report.ClusterHealth = nil
+	summary = NewCheckFormatter().Summarize(report)
+	if !strings.Contains(strings.Join(summary.CriticalIssues, "\n"), "Unassigned Shards: 3") {
+		t.Fatalf("shard health must be used without cluster health: %v", summary.CriticalIssues)
+	}
+}
@@ -0,0 +1,40 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BarChart draws one horizontal bar per row, scaled to the largest value, using plain ASCII
+// so the output survives logs and CI
+type BarChart struct {
+	Width int
+}
+
+func NewBarChart(width int) *BarChart {
+	return &BarChart{Width: width}
+}
+
+// Render draws label | bar | note for each row; labels are padded to the same width
+func (c *BarChart) Render(labels []string, values []int64, notes []string) string {
+	labelWidth := 0
+	var maxValue int64
+	for i, label := range labels {
+		labelWidth = max(labelWidth, len(label))
+		maxValue = max(maxValue, values[i])
+	}
+
+	var b strings.Builder
+	for i, label := range labels {
+		bar := 0
+		if maxValue > 0 {
+			bar = int(float64(values[i]) / float64(maxValue) * float64(c.Width))
+		}
+		if bar == 0 && values[i] > 0 {
+			bar = 1
+		}
+		b.WriteString(fmt.Sprintf("%-*s | %s%s %s\n", labelWidth, label,
+			strings.Repeat("#", bar), strings.Repeat(" ", c.Width-bar), notes[i]))
+	}
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package components
+
+import "strings"
+
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws the last Width values scaled between their min and max
+type Sparkline struct {
+	Width int
+}
+
+func NewSparkline(width int) *Sparkline {
+	return &Sparkline{Width: width}
+}
+
+func (s *Sparkline) Render(values []float64) string {
+	if len(values) > s.Width {
+		values = values[len(values)-s.Width:]
+	}
+	if len(values) == 0 {
+		return strings.Repeat(" ", s.Width)
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		level := len(sparkLevels) / 2
+		if hi > lo {
+			level = int((v - lo) / (hi - lo) * float64(len(sparkLevels)-1))
+		}
+		b.WriteRune(sparkLevels[level])
+	}
+	b.WriteString(strings.Repeat(" ", s.Width-len(values)))
+	return b.String()
+}
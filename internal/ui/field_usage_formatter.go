@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/ui/components"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+type FieldUsageFormatter struct {
+	table *components.Table
+}
+
+func NewFieldUsageFormatter() *FieldUsageFormatter {
+	return &FieldUsageFormatter{table: components.NewTable()}
+}
+
+// FormatFieldUsage renders the accessed fields with the data structures they were read
+// through, then the mapped fields nothing has read
+func (f *FieldUsageFormatter) FormatFieldUsage(report *models.FieldUsageReport, unusedOnly bool) string {
+	var b strings.Builder
+	since := "-"
+	if report.TrackedSinceMillis > 0 {
+		since = time.UnixMilli(report.TrackedSinceMillis).Format("2006-01-02 15:04:05")
+	}
+	b.WriteString(fmt.Sprintf("\nIndex: %s  Shards: %d  Tracked since: %s\n\n", report.Index, report.Shards, since))
+
+	if !unusedOnly {
+		headers := []string{"Field", "Type", "Any", "Inverted Index", "Doc Values", "Stored", "Norms", "Points", "Term Vectors"}
+		rows := make([][]string, 0, len(report.Fields))
+		for _, fu := range report.Fields {
+			rows = append(rows, []string{fu.Field, fu.Type, usageCount(fu.Any), usageCount(fu.InvertedIndex.Terms),
+				usageCount(fu.DocValues), usageCount(fu.StoredFields), usageCount(fu.Norms), usageCount(fu.Points), usageCount(fu.TermVectors)})
+		}
+		if len(rows) > 0 {
+			b.WriteString(f.table.Render(headers, rows))
+			b.WriteString("\n")
+		}
+	}
+
+	if len(report.Unused) == 0 {
+		b.WriteString("Every mapped field has been accessed\n")
+		return b.String()
+	}
+	b.WriteString(fmt.Sprintf("Unused mapped fields (%d):\n", len(report.Unused)))
+	for _, field := range report.Unused {
+		b.WriteString("  " + field + "\n")
+	}
+	b.WriteString("\nUsage restarts from zero when a shard relocates or its node restarts; check the tracking window before removing fields.\n")
+	return b.String()
+}
+
+func usageCount(n int64) string {
+	if n == 0 {
+		return "-"
+	}
+	return util.FormatDocsCount(n)
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/ui/components"
+)
+
+type HotThreadsFormatter struct {
+	table *components.Table
+}
+
+func NewHotThreadsFormatter() *HotThreadsFormatter {
+	return &HotThreadsFormatter{table: components.NewTable()}
+}
+
+// FormatHotThreads renders the busiest threads and the stacks they share
+func (f *HotThreadsFormatter) FormatHotThreads(report *models.HotThreadsReport) string {
+	var b strings.Builder
+	if len(report.Threads) == 0 {
+		b.WriteString(fmt.Sprintf("No hot threads (%s) on %d nodes\n", report.Type, len(report.Nodes)))
+		return b.String()
+	}
+
+	headers := []string{"Node", "Thread", "Pool", strings.ToUpper(report.Type) + " %", "Snapshots", "Top Frame"}
+	rows := make([][]string, 0, len(report.Threads))
+	for _, t := range report.Threads {
+		pool, frame := "-", "-"
+		if t.Pool != "" {
+			pool = t.Pool
+		}
+		if len(t.Stack) > 0 {
+			frame = t.Stack[0]
+		}
+		rows = append(rows, []string{t.Node, t.Thread, pool, fmt.Sprintf("%.1f%%", t.Percent), t.Snapshots, frame})
+	}
+	b.WriteString(f.table.Render(headers, rows))
+	b.WriteString(fmt.Sprintf("Total: %d hot threads on %d nodes\n", len(report.Threads), len(report.Nodes)))
+
+	for i, g := range report.Groups {
+		pools := ""
+		if len(g.Pools) > 0 {
+			pools = ", pools: " + strings.Join(g.Pools, ", ")
+		}
+		b.WriteString(fmt.Sprintf("\nStack %d: %d threads, %.1f%% total, nodes: %s%s\n",
+			i+1, g.Threads, g.TotalPercent, strings.Join(g.Nodes, ", "), pools))
+		for _, frame := range g.Stack {
+			b.WriteString("    " + frame + "\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/ui/components"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+type IndexTopFormatter struct {
+	table *components.Table
+}
+
+func NewIndexTopFormatter() *IndexTopFormatter {
+	return &IndexTopFormatter{table: components.NewTable()}
+}
+
+// FormatIndexTop renders one refresh of 'escope index --top'; infos are already sorted and limited
+func (f *IndexTopFormatter) FormatIndexTop(infos []models.IndexTopInfo, scope, sortBy string, total int, updated time.Time) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Index top: %s | sorted by %s | %d of %d indices | %s (Ctrl+C to quit)\n",
+		scope, sortBy, len(infos), total, updated.Format("15:04:05")))
+
+	headers := []string{"Index", "Search Rate", "Index Rate", "Query Time", "Index Time", "Refresh Time", "Merge Time"}
+	rows := make([][]string, 0, len(infos))
+	for _, info := range infos {
+		r := info.Rates
+		rows = append(rows, []string{info.Name, util.FormatRateOrDash(r.SearchRate), util.FormatRateOrDash(r.IndexRate),
+			util.FormatMillisOrDash(r.AvgQueryTime), util.FormatMillisOrDash(r.AvgIndexTime),
+			util.FormatMillisOrDash(r.AvgRefreshTime), util.FormatMillisOrDash(r.AvgMergeTime)})
+	}
+	b.WriteString(f.table.Render(headers, rows))
+	return b.String()
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/ui/components"
+)
+
+type MappingDiffFormatter struct {
+	table *components.Table
+}
+
+func NewMappingDiffFormatter() *MappingDiffFormatter {
+	return &MappingDiffFormatter{table: components.NewTable()}
+}
+
+// FormatMappingDiff renders the changes from the left index towards the right one
+func (f *MappingDiffFormatter) FormatMappingDiff(left, right string, changes []models.MappingChange) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\nMapping diff: %s -> %s\n\n", left, right))
+	if len(changes) == 0 {
+		b.WriteString("Mappings match\n")
+		return b.String()
+	}
+
+	counts := make(map[string]int)
+	rows := make([][]string, 0, len(changes))
+	for _, c := range changes {
+		change := c.Change
+		if c.Attribute != "" {
+			change = c.Attribute
+		}
+		rows = append(rows, []string{c.Path, change, c.Left, c.Right})
+		counts[c.Change]++
+	}
+	b.WriteString(f.table.Render([]string{"Field", "Change", left, right}, rows))
+	b.WriteString(fmt.Sprintf("Total: %d added, %d removed, %d type changes, %d analyzer changes, %d index toggles\n",
+		counts[models.MappingFieldAdded], counts[models.MappingFieldRemoved], counts[models.MappingTypeChanged],
+		counts[models.MappingAnalyzerChanged], counts[models.MappingIndexToggled]))
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/ui/components"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+type MemoryFormatter struct {
+	table *components.Table
+}
+
+func NewMemoryFormatter() *MemoryFormatter {
+	return &MemoryFormatter{table: components.NewTable()}
+}
+
+// FormatNodeMemory renders the breaker and cache tables of 'escope node memory'
+func (f *MemoryFormatter) FormatNodeMemory(infos []models.NodeMemoryInfo) string {
+	breakerRows := make([][]string, 0)
+	cacheRows := make([][]string, 0)
+	for _, info := range infos {
+		for _, b := range info.Breakers {
+			limit := "-"
+			if b.LimitBytes > 0 {
+				limit = util.FormatBytes(b.LimitBytes)
+			}
+			breakerRows = append(breakerRows, []string{info.Node, b.Name, limit, util.FormatBytes(b.EstimatedBytes),
+				fmt.Sprintf("%.1f%%", b.UsedPercent), strconv.FormatInt(b.Tripped, 10)})
+		}
+		for _, c := range info.Caches {
+			hitRatio := "-"
+			if c.HitRatio >= 0 {
+				hitRatio = fmt.Sprintf("%.1f%%", c.HitRatio)
+			}
+			cacheRows = append(cacheRows, []string{info.Node, c.Name, util.FormatBytes(c.MemoryBytes),
+				strconv.FormatInt(c.HitCount, 10), strconv.FormatInt(c.MissCount, 10), hitRatio, strconv.FormatInt(c.Evictions, 10)})
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("CIRCUIT BREAKERS\n")
+	b.WriteString(f.table.Render([]string{"Node", "Breaker", "Limit", "Estimated", "Used %", "Tripped"}, breakerRows))
+	b.WriteString("\nCACHES\n")
+	b.WriteString(f.table.Render([]string{"Node", "Cache", "Memory", "Hits", "Misses", "Hit %", "Evictions"}, cacheRows))
+
+	for _, info := range infos {
+		parent, ok := info.Breaker(constants.ParentBreaker)
+		if ok && parent.LimitBytes > 0 && parent.UsedPercent >= constants.ParentBreakerWarningPercent {
+			b.WriteString(fmt.Sprintf("Warning: %s\n", fmt.Sprintf(constants.MsgParentBreakerPressure,
+				info.Node, parent.UsedPercent, util.FormatBytes(parent.LimitBytes))))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/ui/components"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+type ProfileFormatter struct {
+	table *components.Table
+}
+
+func NewProfileFormatter() *ProfileFormatter {
+	return &ProfileFormatter{table: components.NewTable()}
+}
+
+// FormatIndexProfile renders one row per profiled field
+func (f *ProfileFormatter) FormatIndexProfile(profile *models.IndexProfile) string {
+	headers := []string{"Field", "Type", "Coverage", "With Field", "Distinct", "Top Values"}
+	rows := make([][]string, 0, len(profile.Fields))
+	var docs int64
+	failed := 0
+	for _, p := range profile.Fields {
+		if p.Error != "" {
+			failed++
+			rows = append(rows, []string{p.Field, p.Type, "-", "-", "-", "error: " + p.Error})
+			continue
+		}
+		if p.Docs > docs {
+			docs = p.Docs
+		}
+		top := "-"
+		if len(p.TopValues) > 0 {
+			values := make([]string, 0, len(p.TopValues))
+			for _, v := range p.TopValues {
+				values = append(values, fmt.Sprintf("%s (%d)", v.Value, v.Count))
+			}
+			top = strings.Join(values, ", ")
+		}
+		rows = append(rows, []string{p.Field, p.Type, fmt.Sprintf("%.1f%%", p.Coverage),
+			util.FormatDocsCount(p.DocsWithField), util.FormatDocsCount(p.Cardinality), top})
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\nIndex: %s\n\n", profile.Index))
+	b.WriteString(f.table.Render(headers, rows))
+	scope := fmt.Sprintf("%s documents", util.FormatDocsCount(docs))
+	if profile.SampleSize > 0 {
+		scope = fmt.Sprintf("%s sampled documents (up to %d per shard)", util.FormatDocsCount(docs), profile.SampleSize)
+	}
+	b.WriteString(fmt.Sprintf("Total: %d fields profiled over %s, %d not aggregatable and skipped\n", len(profile.Fields), scope, profile.Skipped))
+	if failed > 0 {
+		b.WriteString(fmt.Sprintf("Warning: %d fields could not be profiled\n", failed))
+	}
+	return b.String()
+}
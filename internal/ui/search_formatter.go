@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/ui/components"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+const (
+	maxSourceWidth        = 100
+	maxProfileDescWidth   = 80
+	profileBreakdownShown = 3
+)
+
+type SearchFormatter struct {
+	table *components.Table
+}
+
+func NewSearchFormatter() *SearchFormatter {
+	return &SearchFormatter{table: components.NewTable()}
+}
+
+// FormatSearchResult renders the response summary, shard failures, hits and, when profiled,
+// the timing tree of every shard
+func (f *SearchFormatter) FormatSearchResult(result *models.SearchResult, fields []string) string {
+	var b strings.Builder
+	total := util.FormatDocsCount(result.TotalHits)
+	if result.TotalRelation == "gte" {
+		total = "≥" + total
+	}
+	b.WriteString(fmt.Sprintf("\nHits: %s  Took: %dms  Timed out: %t  Shards: %d/%d successful, %d skipped, %d failed\n\n",
+		total, result.Took, result.TimedOut, result.Shards.Successful, result.Shards.Total, result.Shards.Skipped, result.Shards.Failed))
+
+	if len(result.Failures) > 0 {
+		rows := make([][]string, 0, len(result.Failures))
+		for _, sf := range result.Failures {
+			rows = append(rows, []string{sf.Index, fmt.Sprintf("%d", sf.Shard), sf.Node, truncateText(sf.Reason, maxSourceWidth)})
+		}
+		b.WriteString("Shard failures:\n")
+		b.WriteString(f.table.Render([]string{"Index", "Shard", "Node", "Reason"}, rows))
+		b.WriteString("\n")
+	}
+
+	if len(result.Hits) > 0 {
+		headers := []string{"_index", "_id", "_score"}
+		if len(fields) > 0 {
+			headers = append(headers, fields...)
+		} else {
+			headers = append(headers, "_source")
+		}
+		rows := make([][]string, 0, len(result.Hits))
+		for _, hit := range result.Hits {
+			row := []string{hit.Index, hit.ID, fmt.Sprintf("%.3f", hit.Score)}
+			if len(fields) > 0 {
+				for _, field := range fields {
+					row = append(row, hit.Fields[field])
+				}
+			} else {
+				source, _ := json.Marshal(hit.Source)
+				row = append(row, truncateText(string(source), maxSourceWidth))
+			}
+			rows = append(rows, row)
+		}
+		b.WriteString(f.table.Render(headers, rows))
+	}
+
+	for _, shard := range result.Profile {
+		b.WriteString(fmt.Sprintf("\nShard [%s][%d] on %s: query %s, rewrite %s, fetch %s\n",
+			shard.Index, shard.Shard, shard.Node, formatNanos(shard.QueryNanos), formatNanos(shard.RewriteNanos), formatNanos(shard.FetchNanos)))
+		writeProfileSection(&b, "Query", shard.Queries, shard.QueryNanos)
+		writeProfileSection(&b, "Collectors", shard.Collectors, 0)
+		writeProfileSection(&b, "Aggregations", shard.Aggregations, 0)
+	}
+	return b.String()
+}
+
+// writeProfileSection prints a profile tree; percentages are of base, or of the section's
+// own total when base is zero
+func writeProfileSection(b *strings.Builder, title string, nodes []models.ProfileNode, base int64) {
+	if len(nodes) == 0 {
+		return
+	}
+	if base == 0 {
+		for _, n := range nodes {
+			base += n.TimeNanos
+		}
+	}
+	b.WriteString("  " + title + ":\n")
+	for i, n := range nodes {
+		writeProfileNode(b, n, "  ", i == len(nodes)-1, base)
+	}
+}
+
+func writeProfileNode(b *strings.Builder, node models.ProfileNode, prefix string, last bool, base int64) {
+	branch, childPrefix := "├─ ", prefix+"│  "
+	if last {
+		branch, childPrefix = "└─ ", prefix+"   "
+	}
+	percent := 0.0
+	if base > 0 {
+		percent = float64(node.TimeNanos) / float64(base) * 100
+	}
+	line := fmt.Sprintf("%s%s%s %s (%.1f%%)", prefix, branch, node.Type, formatNanos(node.TimeNanos), percent)
+	if node.Description != "" {
+		line += "  " + truncateText(node.Description, maxProfileDescWidth)
+	}
+	if top := topBreakdown(node.Breakdown); top != "" {
+		line += "  [" + top + "]"
+	}
+	b.WriteString(line + "\n")
+	for i, child := range node.Children {
+		writeProfileNode(b, child, childPrefix, i == len(node.Children)-1, base)
+	}
+}
+
+// topBreakdown names the timing components where a query spent most of its time
+func topBreakdown(breakdown map[string]int64) string {
+	keys := make([]string, 0, len(breakdown))
+	for key, nanos := range breakdown {
+		if nanos > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if breakdown[keys[i]] != breakdown[keys[j]] {
+			return breakdown[keys[i]] > breakdown[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > profileBreakdownShown {
+		keys = keys[:profileBreakdownShown]
+	}
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+" "+formatNanos(breakdown[key]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatNanos(nanos int64) string {
+	return fmt.Sprintf("%.3fms", float64(nanos)/1e6)
+}
+
+// FormatQueryExplanation renders the Lucene explanation as an indented scoring breakdown
+func (f *SearchFormatter) FormatQueryExplanation(explanation *models.QueryExplanation) string {
+	var b strings.Builder
+	verdict := "does not match"
+	if explanation.Matched {
+		verdict = "matches"
+	}
+	b.WriteString(fmt.Sprintf("\nDocument %s in %s %s the query\n\n", explanation.ID, explanation.Index, verdict))
+	if explanation.Explanation != nil {
+		writeExplainNode(&b, *explanation.Explanation, 0)
+	}
+	return b.String()
+}
+
+func writeExplainNode(b *strings.Builder, node models.ExplainNode, depth int) {
+	b.WriteString(fmt.Sprintf("%s%.4f  %s\n", strings.Repeat("  ", depth), node.Value, node.Description))
+	for _, detail := range node.Details {
+		writeExplainNode(b, detail, depth+1)
+	}
+}
+
+// FormatQueryValidation renders the rewritten Lucene query of every index, or the reason the
+// query is invalid
+func (f *SearchFormatter) FormatQueryValidation(validation *models.QueryValidation) string {
+	var b strings.Builder
+	if validation.Valid {
+		b.WriteString("\nQuery is valid\n")
+	} else {
+		b.WriteString("\nQuery is NOT valid\n")
+	}
+	for _, e := range validation.Explanations {
+		b.WriteString("\n")
+		if e.Index != "" {
+			b.WriteString("Index: " + e.Index + "\n")
+		}
+		if e.Error != "" {
+			b.WriteString("  Error: " + e.Error + "\n")
+		} else {
+			b.WriteString("  " + e.Explanation + "\n")
+		}
+	}
+	return b.String()
+}
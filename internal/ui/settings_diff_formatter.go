@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/ui/components"
+)
+
+type SettingsDiffFormatter struct {
+	table *components.Table
+}
+
+func NewSettingsDiffFormatter() *SettingsDiffFormatter {
+	return &SettingsDiffFormatter{table: components.NewTable()}
+}
+
+// FormatSettingsDiff renders the settings that differ between the left and right index
+func (f *SettingsDiffFormatter) FormatSettingsDiff(left, right string, changes []models.SettingChange) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\nSettings diff: %s -> %s\n\n", left, right))
+	if len(changes) == 0 {
+		b.WriteString("Settings match\n")
+		return b.String()
+	}
+
+	rows := make([][]string, 0, len(changes))
+	for _, c := range changes {
+		rows = append(rows, []string{c.Key, c.Left, c.Right})
+	}
+	b.WriteString(f.table.Render([]string{"Setting", left, right}, rows))
+	b.WriteString(fmt.Sprintf("Total: %d settings differ\n", len(changes)))
+	return b.String()
+}
+
+// FormatSettingsDrift renders the drift of each verified index from the desired state
+func (f *SettingsDiffFormatter) FormatSettingsDrift(file string, desired int, results []models.IndexSettingsDrift) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\nDesired state: %s (%d settings)\n", file, desired))
+
+	drifted := 0
+	for _, r := range results {
+		if r.InSync {
+			b.WriteString(fmt.Sprintf("\n%s: in sync\n", r.Index))
+			continue
+		}
+		drifted++
+		b.WriteString(fmt.Sprintf("\n%s: %d settings drifted\n", r.Index, len(r.Drift)))
+		rows := make([][]string, 0, len(r.Drift))
+		for _, d := range r.Drift {
+			rows = append(rows, []string{d.Key, d.Expected, d.Actual})
+		}
+		b.WriteString(f.table.Render([]string{"Setting", "Expected", "Actual"}, rows))
+	}
+	b.WriteString(fmt.Sprintf("\nTotal: %d indices, %d in sync, %d drifted\n", len(results), len(results)-drifted, drifted))
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/ui/components"
+)
+
+const maxDeciderExplanationWidth = 100
+
+type AllocationExplainFormatter struct {
+	table *components.Table
+}
+
+func NewAllocationExplainFormatter() *AllocationExplainFormatter {
+	return &AllocationExplainFormatter{table: components.NewTable()}
+}
+
+func (f *AllocationExplainFormatter) FormatAllocationExplain(e *models.AllocationExplain) string {
+	var b strings.Builder
+
+	copyType := constants.ReplicaString
+	if e.Primary {
+		copyType = constants.PrimaryString
+	}
+	b.WriteString(fmt.Sprintf("Shard: %s[%d] %s\n", e.Index, e.Shard, strings.ToLower(copyType)))
+
+	state := e.CurrentState
+	if e.CurrentNode != "" {
+		state += " on " + e.CurrentNode
+	}
+	if e.UnassignedReason != "" {
+		state += fmt.Sprintf(" (%s since %s", e.UnassignedReason, e.UnassignedSince)
+		if e.FailedAttempts > 0 {
+			state += fmt.Sprintf(", %d failed attempts", e.FailedAttempts)
+		}
+		state += ")"
+	}
+	b.WriteString(fmt.Sprintf("State: %s\n", state))
+	if e.UnassignedDetails != "" {
+		b.WriteString(fmt.Sprintf("Details: %s\n", e.UnassignedDetails))
+	}
+	if e.CanAllocate != "" || e.Explanation != "" {
+		b.WriteString(fmt.Sprintf("Decision: %s\n", strings.TrimPrefix(strings.TrimSpace(e.CanAllocate+" - "+e.Explanation), "- ")))
+	}
+
+	if len(e.NodeDecisions) > 0 {
+		rows := make([][]string, 0, len(e.NodeDecisions))
+		for _, n := range e.NodeDecisions {
+			names := make([]string, 0, len(n.Deciders))
+			reason := constants.DashString
+			for _, d := range n.Deciders {
+				names = append(names, d.Decider)
+			}
+			if len(n.Deciders) > 0 {
+				reason = truncateText(n.Deciders[0].Explanation, maxDeciderExplanationWidth)
+			}
+			decider := constants.DashString
+			if len(names) > 0 {
+				decider = strings.Join(names, ", ")
+			}
+			rows = append(rows, []string{n.NodeName, n.Decision, decider, reason})
+		}
+		b.WriteString("\n")
+		b.WriteString(f.table.Render([]string{"Node", "Decision", "Deciders", "Reason"}, rows))
+	}
+
+	if len(e.Hints) > 0 {
+		b.WriteString("\nHints:\n")
+		for _, hint := range e.Hints {
+			b.WriteString("  - " + hint + "\n")
+		}
+	}
+	return b.String()
+}
+
+func truncateText(s string, width int) string {
+	if len(s) <= width {
+		return s
+	}
+	return s[:width-3] + "..."
+}
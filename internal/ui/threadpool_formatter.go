@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/ui/components"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+type ThreadPoolFormatter struct {
+	table *components.Table
+}
+
+func NewThreadPoolFormatter() *ThreadPoolFormatter {
+	return &ThreadPoolFormatter{table: components.NewTable()}
+}
+
+// FormatThreadPools renders the pools of 'escope node threadpool'. A non-zero window adds the
+// rejections and rates measured between the two snapshots.
+func (f *ThreadPoolFormatter) FormatThreadPools(pools []models.ThreadPoolStats, window time.Duration) string {
+	headers := []string{"Node", "Pool", "Threads", "Active", "Queue", "Largest", "Rejected", "Completed"}
+	if window > 0 {
+		headers = append(headers, "Rejected +", "Rejected/s", "Completed/s")
+	}
+
+	rows := make([][]string, 0, len(pools))
+	var totalRejected, windowRejected int64
+	rejectingPools := 0
+	for _, p := range pools {
+		row := []string{p.Node, p.Pool, strconv.FormatInt(p.Threads, 10), strconv.FormatInt(p.Active, 10),
+			strconv.FormatInt(p.Queue, 10), strconv.FormatInt(p.Largest, 10),
+			strconv.FormatInt(p.Rejected, 10), util.FormatDocsCount(p.Completed)}
+		if window > 0 {
+			row = append(row, strconv.FormatInt(p.RejectedDelta, 10), util.FormatRateOrDash(p.RejectedRate), util.FormatRateOrDash(p.CompletedRate))
+		}
+		rows = append(rows, row)
+
+		totalRejected += p.Rejected
+		windowRejected += p.RejectedDelta
+		if p.RejectedDelta > 0 {
+			rejectingPools++
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(f.table.Render(headers, rows))
+	b.WriteString(fmt.Sprintf("Total: %d pools, %d rejected since node start\n", len(pools), totalRejected))
+	if window > 0 {
+		if windowRejected > 0 {
+			b.WriteString(fmt.Sprintf("Warning: %d rejections in %d pools during the %s sample\n", windowRejected, rejectingPools, window))
+		} else {
+			b.WriteString(fmt.Sprintf("No rejections during the %s sample\n", window))
+		}
+	} else if totalRejected > 0 {
+		b.WriteString("Rejected counts are cumulative; use --sample to see whether they are still growing\n")
+	}
+	return b.String()
+}
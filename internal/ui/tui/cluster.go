@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -23,6 +24,14 @@ type ClusterModel struct {
 	table       *components.Table
 	panel       *components.Panel
 	progressBar *components.ProgressBar
+
+	// watch mode only
+	watch       bool
+	interval    time.Duration
+	history     []clusterSample
+	transitions []statusTransition
+	updated     time.Time
+	sparkline   *components.Sparkline
 }
 
 type statsMsg struct {
@@ -30,6 +39,28 @@ type statsMsg struct {
 	err   error
 }
 
+type clusterTickMsg time.Time
+
+const (
+	maxClusterHistory     = 60
+	maxStatusTransitions  = 8
+	clusterSparklineWidth = 30
+)
+
+type clusterSample struct {
+	heap  float64
+	cpu   float64
+	disk  float64
+	docs  int64
+	store int64
+}
+
+type statusTransition struct {
+	at   time.Time
+	from string
+	to   string
+}
+
 func NewClusterModel(service services.ClusterService) ClusterModel {
 	return ClusterModel{
 		service:     service,
@@ -40,6 +71,15 @@ func NewClusterModel(service services.ClusterService) ClusterModel {
 	}
 }
 
+// NewClusterWatchModel keeps refreshing the stats every interval and draws their history
+func NewClusterWatchModel(service services.ClusterService, interval time.Duration) ClusterModel {
+	m := NewClusterModel(service)
+	m.watch = true
+	m.interval = interval
+	m.sparkline = components.NewSparkline(clusterSparklineWidth)
+	return m
+}
+
 func (m ClusterModel) Init() tea.Cmd {
 	return m.fetchStats()
 }
@@ -57,22 +97,124 @@ func (m ClusterModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			m.err = msg.err
 		} else {
+			if m.watch {
+				m.record(msg.stats)
+			}
 			m.stats = msg.stats
 			m.err = nil
 		}
+		if m.watch {
+			return m, tea.Tick(m.interval, func(t time.Time) tea.Msg { return clusterTickMsg(t) })
+		}
 		return m, tea.Sequence(tea.Println(m.renderOutput()), tea.Quit)
+
+	case clusterTickMsg:
+		return m, m.fetchStats()
 	}
 
 	return m, nil
 }
 
+// record appends a sample to the rolling history and notes a health status change
+func (m *ClusterModel) record(stats *models.ClusterStats) {
+	now := time.Now()
+	if m.stats != nil && m.stats.Status != stats.Status {
+		m.transitions = append(m.transitions, statusTransition{at: now, from: m.stats.Status, to: stats.Status})
+		if len(m.transitions) > maxStatusTransitions {
+			m.transitions = m.transitions[len(m.transitions)-maxStatusTransitions:]
+		}
+	}
+
+	m.history = append(m.history, clusterSample{
+		heap:  stats.HeapUsagePercent,
+		cpu:   stats.CPUUsagePercent,
+		disk:  stats.DiskUsagePercent,
+		docs:  stats.TotalDocuments,
+		store: stats.UsedDiskBytes,
+	})
+	if len(m.history) > maxClusterHistory {
+		m.history = m.history[len(m.history)-maxClusterHistory:]
+	}
+	m.updated = now
+}
+
 func (m ClusterModel) View() string {
 	if m.loading {
 		return "Loading...\n"
 	}
+	if m.watch {
+		return m.renderWatch()
+	}
 	return ""
 }
 
+func (m ClusterModel) renderWatch() string {
+	var b strings.Builder
+
+	b.WriteString(m.renderHeader())
+	b.WriteString(fmt.Sprintf("  refresh %s, updated %s  (q to quit)\n\n", m.interval, m.updated.Format("15:04:05")))
+
+	if m.err != nil {
+		b.WriteString(fmt.Sprintf("Error: %v\n\n", m.err))
+	}
+	if m.stats == nil {
+		return b.String()
+	}
+
+	b.WriteString(m.renderResources())
+	b.WriteString("\n")
+	b.WriteString(m.renderTrends())
+	b.WriteString("\n")
+	b.WriteString(m.renderMetrics())
+	b.WriteString("\n")
+	b.WriteString(m.renderTransitions())
+
+	return b.String()
+}
+
+func (m ClusterModel) renderTrends() string {
+	series := func(get func(clusterSample) float64) []float64 {
+		values := make([]float64, len(m.history))
+		for i, s := range m.history {
+			values[i] = get(s)
+		}
+		return values
+	}
+	first, last := m.history[0], m.history[len(m.history)-1]
+
+	lines := []string{
+		fmt.Sprintf("%-8s %s  %5.1f%%", "HEAP", m.sparkline.Render(series(func(s clusterSample) float64 { return s.heap })), last.heap),
+		fmt.Sprintf("%-8s %s  %5.1f%%", "CPU", m.sparkline.Render(series(func(s clusterSample) float64 { return s.cpu })), last.cpu),
+		fmt.Sprintf("%-8s %s  %5.1f%%", "DISK", m.sparkline.Render(series(func(s clusterSample) float64 { return s.disk })), last.disk),
+		fmt.Sprintf("%-8s %s  %s (%+d)", "DOCS", m.sparkline.Render(series(func(s clusterSample) float64 { return float64(s.docs) })),
+			util.FormatDocsCount(last.docs), last.docs-first.docs),
+		fmt.Sprintf("%-8s %s  %s (%s)", "STORE", m.sparkline.Render(series(func(s clusterSample) float64 { return float64(s.store) })),
+			util.FormatBytes(last.store), signedBytes(last.store-first.store)),
+	}
+	return components.NewPanel(fmt.Sprintf("TRENDS (%d samples)", len(m.history))).Render(lines)
+}
+
+func signedBytes(delta int64) string {
+	if delta < 0 {
+		return "-" + util.FormatBytes(-delta)
+	}
+	return "+" + util.FormatBytes(delta)
+}
+
+func (m ClusterModel) renderTransitions() string {
+	if len(m.transitions) == 0 {
+		return "Status changes: none since start\n"
+	}
+
+	var b strings.Builder
+	b.WriteString("Status changes:\n")
+	for i := len(m.transitions) - 1; i >= 0; i-- {
+		t := m.transitions[i]
+		b.WriteString(fmt.Sprintf("  %s  %s -> %s\n", t.at.Format("15:04:05"), statusBadge(t.from), statusBadge(t.to)))
+	}
+	return b.String()
+}
+
 func (m ClusterModel) renderOutput() string {
 	var b strings.Builder
 
@@ -118,22 +260,22 @@ func (m ClusterModel) renderHeader() string {
 		return "Cluster: loading..."
 	}
 
-	var badgeStyle lipgloss.Style
-	switch m.stats.Status {
+	nameStyle := lipgloss.NewStyle().Bold(true)
+	name := nameStyle.Render(m.stats.ClusterName)
+	return fmt.Sprintf("Cluster: %s  %s", name, statusBadge(m.stats.Status))
+}
+
+func statusBadge(status string) string {
+	style := lipgloss.NewStyle()
+	switch status {
 	case "green":
-		badgeStyle = lipgloss.NewStyle().Background(lipgloss.Color("42")).Foreground(lipgloss.Color("0"))
+		style = style.Background(lipgloss.Color("42")).Foreground(lipgloss.Color("0"))
 	case "yellow":
-		badgeStyle = lipgloss.NewStyle().Background(lipgloss.Color("226")).Foreground(lipgloss.Color("0"))
+		style = style.Background(lipgloss.Color("226")).Foreground(lipgloss.Color("0"))
 	case "red":
-		badgeStyle = lipgloss.NewStyle().Background(lipgloss.Color("196")).Foreground(lipgloss.Color("15"))
-	default:
-		badgeStyle = lipgloss.NewStyle()
+		style = style.Background(lipgloss.Color("196")).Foreground(lipgloss.Color("15"))
 	}
-
-	nameStyle := lipgloss.NewStyle().Bold(true)
-	name := nameStyle.Render(m.stats.ClusterName)
-	badge := badgeStyle.Render(" " + strings.ToUpper(m.stats.Status) + " ")
-	return fmt.Sprintf("Cluster: %s  %s", name, badge)
+	return style.Render(" " + strings.ToUpper(status) + " ")
 }
 
 func (m ClusterModel) renderResources() string {
@@ -187,9 +329,14 @@ func (m ClusterModel) renderSystem() string {
 		jvmVersions = strings.Join(m.stats.JVMVersions, ", ")
 	}
 
+	distribution := "Elasticsearch"
+	if m.stats.Distribution == "opensearch" {
+		distribution = "OpenSearch"
+	}
+
 	headers := []string{"System Info", "Value"}
 	rows := [][]string{
-		{"ES Version", m.stats.ESVersion},
+		{"Version", distribution + " " + m.stats.ESVersion},
 		{"JVM Versions", jvmVersions},
 	}
 
@@ -201,3 +348,9 @@ func RunClusterTUI(service services.ClusterService) error {
 	_, err := p.Run()
 	return err
 }
+
+func RunClusterWatchTUI(service services.ClusterService, interval time.Duration) error {
+	p := tea.NewProgram(NewClusterWatchModel(service, interval), tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
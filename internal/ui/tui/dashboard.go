@@ -0,0 +1,514 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+const maxDashboardCellWidth = 48
+
+var (
+	dashboardTitleStyle    = lipgloss.NewStyle().Bold(true)
+	dashboardActiveTab     = lipgloss.NewStyle().Bold(true).Background(lipgloss.Color("62")).Foreground(lipgloss.Color("15")).Padding(0, 1)
+	dashboardInactiveTab   = lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Padding(0, 1)
+	dashboardHeaderStyle   = lipgloss.NewStyle().Bold(true).Underline(true)
+	dashboardSelectedStyle = lipgloss.NewStyle().Reverse(true)
+	dashboardHintStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	dashboardErrorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+)
+
+// DashboardModel is the full-screen 'escope ui' view: one tab per resource, auto refresh,
+// filtering, sorting and drill-down into indices and nodes
+type DashboardModel struct {
+	services DashboardServices
+	host     string
+	interval time.Duration
+
+	tab     dashboardTab
+	data    map[dashboardTab]tabData
+	errs    map[dashboardTab]error
+	loading map[dashboardTab]bool
+	updated map[dashboardTab]time.Time
+
+	cursor     int
+	offset     int
+	sortCol    int
+	sortDesc   bool
+	filter     string
+	filtering  bool
+	showSystem bool
+
+	detailKey    string
+	detailTab    dashboardTab
+	detail       string
+	detailErr    error
+	detailScroll int
+
+	width  int
+	height int
+}
+
+type dashboardDataMsg struct {
+	tab  dashboardTab
+	data tabData
+	err  error
+}
+
+type dashboardDetailMsg struct {
+	key  string
+	body string
+	err  error
+}
+
+type dashboardTickMsg time.Time
+
+func NewDashboardModel(svc DashboardServices, host string, interval time.Duration) *DashboardModel {
+	return &DashboardModel{
+		services: svc,
+		host:     host,
+		interval: interval,
+		data:     make(map[dashboardTab]tabData),
+		errs:     make(map[dashboardTab]error),
+		loading:  make(map[dashboardTab]bool),
+		updated:  make(map[dashboardTab]time.Time),
+		width:    100,
+		height:   30,
+	}
+}
+
+func (m *DashboardModel) Init() tea.Cmd {
+	return tea.Batch(m.load(tabCluster), m.tick())
+}
+
+func (m *DashboardModel) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(t time.Time) tea.Msg { return dashboardTickMsg(t) })
+}
+
+func (m *DashboardModel) load(tab dashboardTab) tea.Cmd {
+	if m.loading[tab] {
+		return nil
+	}
+	m.loading[tab] = true
+	svc := m.services
+	return func() tea.Msg {
+		data, err := util.ExecuteWithTimeout(func() (tabData, error) {
+			return fetchTab(context.Background(), svc, tab)
+		})
+		return dashboardDataMsg{tab: tab, data: data, err: timeoutError(err)}
+	}
+}
+
+func (m *DashboardModel) loadDetail() tea.Cmd {
+	svc, tab, key := m.services, m.detailTab, m.detailKey
+	return func() tea.Msg {
+		body, err := util.ExecuteWithTimeout(func() (string, error) {
+			return fetchDetail(context.Background(), svc, tab, key)
+		})
+		return dashboardDetailMsg{key: key, body: body, err: timeoutError(err)}
+	}
+}
+
+func timeoutError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return errors.New(constants.MsgTimeoutGeneric)
+	}
+	return err
+}
+
+func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.clamp()
+		m.clampDetail()
+		return m, nil
+
+	case dashboardTickMsg:
+		cmds := []tea.Cmd{m.tick(), m.load(m.tab)}
+		if m.detailKey != "" {
+			cmds = append(cmds, m.loadDetail())
+		}
+		return m, tea.Batch(cmds...)
+
+	case dashboardDataMsg:
+		m.loading[msg.tab] = false
+		m.errs[msg.tab] = msg.err
+		if msg.err == nil {
+			m.data[msg.tab] = msg.data
+			m.updated[msg.tab] = time.Now()
+			if msg.tab != tabCluster && m.data[tabCluster].stats == nil {
+				return m, m.load(tabCluster)
+			}
+		}
+		m.clamp()
+		return m, nil
+
+	case dashboardDetailMsg:
+		if msg.key == m.detailKey {
+			m.detail, m.detailErr = msg.body, msg.err
+			m.clampDetail()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.filtering {
+			return m, m.updateFilter(msg)
+		}
+		if m.detailKey != "" {
+			return m, m.updateDetail(msg)
+		}
+		return m, m.updateList(msg)
+	}
+	return m, nil
+}
+
+func (m *DashboardModel) updateFilter(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.filtering = false
+	case tea.KeyEsc:
+		m.filtering = false
+		m.filter = ""
+	case tea.KeyBackspace:
+		if len(m.filter) > 0 {
+			m.filter = m.filter[:len(m.filter)-1]
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.filter += string(msg.Runes)
+	case tea.KeyCtrlC:
+		return tea.Quit
+	}
+	m.cursor, m.offset = 0, 0
+	return nil
+}
+
+func (m *DashboardModel) updateDetail(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return tea.Quit
+	case "esc", "backspace", "left", "h":
+		m.detailKey, m.detail, m.detailErr = "", "", nil
+	case "up", "k":
+		m.detailScroll--
+	case "down", "j":
+		m.detailScroll++
+	case "pgup":
+		m.detailScroll -= m.pageSize()
+	case "pgdown", " ":
+		m.detailScroll += m.pageSize()
+	case "r":
+		return m.loadDetail()
+	}
+	m.clampDetail()
+	return nil
+}
+
+func (m *DashboardModel) updateList(msg tea.KeyMsg) tea.Cmd {
+	switch key := msg.String(); key {
+	case "ctrl+c", "q":
+		return tea.Quit
+	case "tab", "right", "l":
+		return m.switchTab((m.tab + 1) % dashboardTab(len(dashboardTabNames)))
+	case "shift+tab", "left", "h":
+		return m.switchTab((m.tab + dashboardTab(len(dashboardTabNames)) - 1) % dashboardTab(len(dashboardTabNames)))
+	case "1", "2", "3", "4", "5", "6":
+		return m.switchTab(dashboardTab(key[0] - '1'))
+	case "up", "k":
+		m.cursor--
+	case "down", "j":
+		m.cursor++
+	case "pgup":
+		m.cursor -= m.pageSize()
+	case "pgdown":
+		m.cursor += m.pageSize()
+	case "g", "home":
+		m.cursor = 0
+	case "G", "end":
+		m.cursor = len(m.visibleRows())
+	case "/":
+		m.filtering = true
+	case "esc":
+		m.filter = ""
+	case "s":
+		if headers := m.data[m.tab].headers; len(headers) > 0 {
+			m.sortCol = (m.sortCol + 1) % len(headers)
+		}
+	case "S":
+		m.sortDesc = !m.sortDesc
+	case "a":
+		m.showSystem = !m.showSystem
+	case "r":
+		return m.load(m.tab)
+	case "enter":
+		rows := m.visibleRows()
+		if m.tab.drillable() && m.cursor < len(rows) {
+			m.detailTab, m.detailKey = m.tab, rows[m.cursor][0]
+			m.detail, m.detailErr, m.detailScroll = "", nil, 0
+			return m.loadDetail()
+		}
+	}
+	m.clamp()
+	return nil
+}
+
+func (m *DashboardModel) switchTab(tab dashboardTab) tea.Cmd {
+	m.tab = tab
+	m.cursor, m.offset, m.sortCol, m.sortDesc = 0, 0, 0, false
+	return m.load(tab)
+}
+
+// visibleRows applies the system index toggle, the filter and the sort order
+func (m *DashboardModel) visibleRows() [][]string {
+	data := m.data[m.tab]
+	filter := strings.ToLower(m.filter)
+	rows := make([][]string, 0, len(data.rows))
+	for i, row := range data.rows {
+		if m.tab.hasSystemRows() && !m.showSystem && i < len(data.system) && data.system[i] {
+			continue
+		}
+		if filter != "" && !strings.Contains(strings.ToLower(strings.Join(row, " ")), filter) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	if m.tab == tabCluster {
+		return rows
+	}
+
+	col := m.sortCol
+	sort.SliceStable(rows, func(i, j int) bool {
+		return lessCell(cellAt(rows[i], col), cellAt(rows[j], col), m.sortDesc)
+	})
+	return rows
+}
+
+// lessCell orders numeric cells by value and the rest as text. In a column mixing both,
+// cells that don't parse as numbers stay last in either direction.
+func lessCell(a, b string, desc bool) bool {
+	av, aok := sortValue(a)
+	bv, bok := sortValue(b)
+	switch {
+	case aok != bok:
+		return aok
+	case aok && desc:
+		return bv < av
+	case aok:
+		return av < bv
+	case desc:
+		return b < a
+	default:
+		return a < b
+	}
+}
+
+func cellAt(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
+}
+
+func (m *DashboardModel) pageSize() int {
+	// title, tabs, blank, table header, blank, status, help
+	return max(1, m.height-7)
+}
+
+func (m *DashboardModel) clamp() {
+	n := len(m.visibleRows())
+	if m.cursor >= n {
+		m.cursor = n - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	page := m.pageSize()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+page {
+		m.offset = m.cursor - page + 1
+	}
+}
+
+func (m *DashboardModel) detailLines() []string {
+	return strings.Split(strings.TrimRight(m.detail, "\n"), "\n")
+}
+
+func (m *DashboardModel) detailPageSize() int {
+	// the detail view replaces the tabs and table header with a single title line
+	return max(1, m.pageSize()-1)
+}
+
+// clampDetail keeps the detail scroll position within the loaded detail text
+func (m *DashboardModel) clampDetail() {
+	m.detailScroll = max(0, min(m.detailScroll, len(m.detailLines())-m.detailPageSize()))
+}
+
+func (m *DashboardModel) View() string {
+	var b strings.Builder
+	b.WriteString(m.renderTitle())
+	b.WriteString("\n")
+	b.WriteString(m.renderTabs())
+	b.WriteString("\n\n")
+
+	if m.detailKey != "" {
+		b.WriteString(m.renderDetail())
+	} else {
+		b.WriteString(m.renderTable())
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderStatus())
+	return b.String()
+}
+
+func (m *DashboardModel) renderTitle() string {
+	title := dashboardTitleStyle.Render("escope") + "  " + m.host
+	if stats := m.data[tabCluster].stats; stats != nil {
+		title += "  " + dashboardTitleStyle.Render(stats.ClusterName) + "  " + statusBadge(stats.Status)
+	}
+	return title
+}
+
+func (m *DashboardModel) renderTabs() string {
+	parts := make([]string, 0, len(dashboardTabNames))
+	for i, name := range dashboardTabNames {
+		label := fmt.Sprintf("%d %s", i+1, name)
+		if dashboardTab(i) == m.tab {
+			parts = append(parts, dashboardActiveTab.Render(label))
+		} else {
+			parts = append(parts, dashboardInactiveTab.Render(label))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func (m *DashboardModel) renderTable() string {
+	data, ok := m.data[m.tab]
+	if !ok {
+		if err := m.errs[m.tab]; err != nil {
+			return dashboardErrorStyle.Render("Error: "+err.Error()) + "\n"
+		}
+		return "Loading...\n"
+	}
+
+	rows := m.visibleRows()
+	widths := make([]int, len(data.headers))
+	for i, h := range data.headers {
+		widths[i] = len(h) + 2 // room for the sort arrow
+	}
+	for _, row := range rows {
+		for i := range widths {
+			widths[i] = max(widths[i], min(len(cellAt(row, i)), maxDashboardCellWidth))
+		}
+	}
+
+	var b strings.Builder
+	headers := make([]string, len(data.headers))
+	for i, h := range data.headers {
+		if i == m.sortCol && m.tab != tabCluster {
+			if m.sortDesc {
+				h += " ↓"
+			} else {
+				h += " ↑"
+			}
+		}
+		headers[i] = dashboardHeaderStyle.Render(pad(h, widths[i]))
+	}
+	b.WriteString(strings.Join(headers, "  "))
+	b.WriteString("\n")
+
+	if len(rows) == 0 {
+		b.WriteString("No data found\n")
+		return b.String()
+	}
+	end := min(len(rows), m.offset+m.pageSize())
+	for i := m.offset; i < end; i++ {
+		cells := make([]string, len(widths))
+		for c := range widths {
+			cells[c] = pad(truncate(cellAt(rows[i], c), maxDashboardCellWidth), widths[c])
+		}
+		line := strings.Join(cells, "  ")
+		if i == m.cursor {
+			line = dashboardSelectedStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (m *DashboardModel) renderDetail() string {
+	var b strings.Builder
+	b.WriteString(dashboardTitleStyle.Render(dashboardTabNames[m.detailTab] + " / " + m.detailKey))
+	b.WriteString("\n")
+	switch {
+	case m.detailErr != nil:
+		b.WriteString(dashboardErrorStyle.Render("Error: " + m.detailErr.Error()))
+		b.WriteString("\n")
+	case m.detail == "":
+		b.WriteString("Loading...\n")
+	default:
+		lines := m.detailLines()
+		start := min(m.detailScroll, len(lines))
+		end := min(len(lines), start+m.detailPageSize())
+		b.WriteString(strings.Join(lines[start:end], "\n"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (m *DashboardModel) renderStatus() string {
+	var status []string
+	if m.filtering {
+		status = append(status, "Filter: "+m.filter+"█")
+	} else if m.filter != "" {
+		status = append(status, "Filter: "+m.filter)
+	}
+	if m.detailKey == "" {
+		status = append(status, fmt.Sprintf("%d rows", len(m.visibleRows())))
+		if m.tab.hasSystemRows() && m.showSystem {
+			status = append(status, "system indices shown")
+		}
+	}
+	if t, ok := m.updated[m.tab]; ok {
+		status = append(status, "updated "+t.Format("15:04:05"))
+	}
+	if err := m.errs[m.tab]; err != nil && m.data[m.tab].headers != nil {
+		status = append(status, dashboardErrorStyle.Render("refresh failed: "+err.Error()))
+	}
+
+	help := "tab/1-6 switch  ↑↓ move  / filter  s sort  S reverse  a system  enter open  r refresh  q quit"
+	if m.detailKey != "" {
+		help = "↑↓ scroll  esc back  r refresh  q quit"
+	}
+	return strings.Join(status, " | ") + "\n" + dashboardHintStyle.Render(help)
+}
+
+func pad(s string, width int) string {
+	if n := lipgloss.Width(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// truncate cuts s to width terminal cells, measured the same way as pad
+func truncate(s string, width int) string {
+	return ansi.Truncate(s, width, "…")
+}
+
+func RunDashboard(svc DashboardServices, host string, interval time.Duration) error {
+	p := tea.NewProgram(NewDashboardModel(svc, host, interval), tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
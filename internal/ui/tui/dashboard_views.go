@@ -0,0 +1,215 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/services"
+	"github.com/mertbahardogan/escope/internal/ui"
+	"github.com/mertbahardogan/escope/internal/ui/components"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+// DashboardServices are the services the dashboard reads from
+type DashboardServices struct {
+	Cluster  services.ClusterService
+	Node     services.NodeService
+	Index    services.IndexService
+	Shard    services.ShardService
+	Segments services.SegmentsService
+	GC       services.GCService
+}
+
+type dashboardTab int
+
+const (
+	tabCluster dashboardTab = iota
+	tabNodes
+	tabIndices
+	tabShards
+	tabSegments
+	tabGC
+)
+
+var dashboardTabNames = []string{"Cluster", "Nodes", "Indices", "Shards", "Segments", "GC"}
+
+// tabData is one fetched table; system marks rows that belong to system indices
+type tabData struct {
+	headers []string
+	rows    [][]string
+	system  []bool
+	stats   *models.ClusterStats
+}
+
+// drillable reports whether enter opens a detail view, using the first column as its key
+func (t dashboardTab) drillable() bool {
+	return t == tabNodes || t == tabIndices || t == tabGC
+}
+
+// hasSystemRows reports whether the tab lists indices that can be hidden with 'a'
+func (t dashboardTab) hasSystemRows() bool {
+	return t == tabIndices || t == tabShards || t == tabSegments
+}
+
+func fetchTab(ctx context.Context, svc DashboardServices, tab dashboardTab) (tabData, error) {
+	switch tab {
+	case tabCluster:
+		stats, err := svc.Cluster.GetClusterStats(ctx)
+		if err != nil {
+			return tabData{}, err
+		}
+		return clusterTab(stats), nil
+
+	case tabNodes:
+		nodes, err := svc.Node.GetNodesInfo(ctx)
+		if err != nil {
+			return tabData{}, err
+		}
+		data := tabData{headers: []string{"Name", "IP", "Roles", "CPU %", "Mem %", "Heap %", "Disk %", "Disk Avail", "Docs"}}
+		for _, n := range nodes {
+			data.rows = append(data.rows, []string{n.Name, n.IP, strings.Join(n.Roles, ","), n.CPUPercent, n.MemPercent,
+				n.HeapPercent, n.DiskPercent, n.DiskAvail, strconv.FormatInt(n.Documents, 10)})
+			data.system = append(data.system, false)
+		}
+		return data, nil
+
+	case tabIndices:
+		indices, err := svc.Index.GetAllIndexInfos(ctx)
+		if err != nil {
+			return tabData{}, err
+		}
+		data := tabData{headers: []string{"Name", "Alias", "Health", "Status", "Docs", "Size", "Pri", "Rep"}}
+		for _, i := range indices {
+			data.rows = append(data.rows, []string{i.Name, i.Alias, i.Health, i.Status, i.DocsCount, i.StoreSize, i.Primary, i.Replica})
+			data.system = append(data.system, util.IsSystemIndex(i.Name))
+		}
+		return data, nil
+
+	case tabShards:
+		shards, err := svc.Shard.GetAllShardInfos(ctx)
+		if err != nil {
+			return tabData{}, err
+		}
+		data := tabData{headers: []string{"Index", "Shard", "Type", "State", "Docs", "Store", "Node", "IP"}}
+		for _, s := range shards {
+			data.rows = append(data.rows, []string{s.Index, s.Shard, util.ConvertShardName(s.Prirep), s.State, s.Docs, s.Store, s.Node, s.IP})
+			data.system = append(data.system, util.IsSystemIndex(s.Index))
+		}
+		return data, nil
+
+	case tabSegments:
+		segments, err := svc.Segments.GetSegmentsInfo(ctx)
+		if err != nil {
+			return tabData{}, err
+		}
+		data := tabData{headers: []string{"Index", "Segments", "Size"}}
+		for _, s := range segments {
+			data.rows = append(data.rows, []string{s.Index, strconv.Itoa(s.SegmentCount), util.FormatBytes(s.SizeBytes)})
+			data.system = append(data.system, util.IsSystemIndex(s.Index))
+		}
+		return data, nil
+
+	case tabGC:
+		infos, err := svc.GC.GetGCInfo(ctx)
+		if err != nil {
+			return tabData{}, err
+		}
+		data := tabData{headers: []string{"Name", "Heap %", "Old Gen %", "Young GC", "Young Avg", "Old GC", "Old Avg", "Pressure"}}
+		for _, g := range infos {
+			data.rows = append(data.rows, []string{g.NodeName, fmt.Sprintf("%.1f%%", g.TotalHeap.Percent), fmt.Sprintf("%.1f%%", g.OldGeneration.Percent),
+				g.YoungGC.CountStr, g.YoungGC.AvgTimeStr, g.OldGC.CountStr, g.OldGC.AvgTimeStr, g.Performance.MemoryPressure})
+			data.system = append(data.system, false)
+		}
+		return data, nil
+	}
+	return tabData{}, fmt.Errorf("unknown tab %d", tab)
+}
+
+func clusterTab(stats *models.ClusterStats) tabData {
+	data := tabData{headers: []string{"Metric", "Value"}, stats: stats}
+	add := func(metric, value string) {
+		data.rows = append(data.rows, []string{metric, value})
+		data.system = append(data.system, false)
+	}
+	add("Status", strings.ToUpper(stats.Status))
+	add("Nodes", fmt.Sprintf("%d (%s)", stats.TotalNodes, stats.GetNodeBreakdown()))
+	add("Indices", strconv.Itoa(stats.TotalIndices))
+	add("Documents", util.FormatDocsCount(stats.TotalDocuments))
+	add("Primary Shards", strconv.Itoa(stats.PrimaryShards))
+	add("Total Shards", strconv.Itoa(stats.TotalShards))
+	add("Avg Shard Size", fmt.Sprintf("%.2f GB", stats.AvgShardSizeGB))
+	add("Disk", fmt.Sprintf("%.1f%% (%s / %s)", stats.DiskUsagePercent, util.FormatBytes(stats.TotalDiskBytes-stats.AvailableDiskBytes), util.FormatBytes(stats.TotalDiskBytes)))
+	add("Heap", fmt.Sprintf("%.1f%% (%s / %s)", stats.HeapUsagePercent, util.FormatBytes(stats.UsedHeapBytes), util.FormatBytes(stats.TotalHeapBytes)))
+	add("Memory", fmt.Sprintf("%.1f%% (%s / %s)", stats.MemoryUsagePercent, util.FormatBytes(stats.UsedMemoryBytes), util.FormatBytes(stats.TotalMemoryBytes)))
+	add("Version", strings.TrimSpace(stats.Distribution+" "+stats.ESVersion))
+	return data
+}
+
+// fetchDetail renders the drill-down view of a node (GC) or an index (rates, mapping, settings)
+func fetchDetail(ctx context.Context, svc DashboardServices, tab dashboardTab, key string) (string, error) {
+	table := components.NewTable()
+
+	if tab == tabNodes || tab == tabGC {
+		info, err := svc.GC.GetGCInfoForNode(ctx, key)
+		if err != nil {
+			return "", err
+		}
+		return ui.NewGCFormatter().FormatGCDetails(*info), nil
+	}
+
+	var b strings.Builder
+	detail, err := svc.Index.GetIndexDetailInfo(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	b.WriteString("Rates (refined on every refresh)\n")
+	b.WriteString(table.Render([]string{"Search Rate", "Index Rate", "Avg Query Time", "Avg Index Time"},
+		[][]string{{detail.SearchRate, detail.IndexRate, detail.AvgQueryTime, detail.AvgIndexTime}}))
+
+	mappings, err := svc.Index.GetIndexMapping(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	rows := make([][]string, 0, len(mappings))
+	for _, f := range mappings {
+		rows = append(rows, []string{f.Path, f.Type, f.Analyzer, f.Index})
+	}
+	b.WriteString("\nMapping\n")
+	b.WriteString(table.Render([]string{"Field", "Type", "Analyzer", "Index"}, rows))
+
+	settings, err := svc.Index.GetIndexSettings(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	rows = make([][]string, 0, len(settings))
+	for _, s := range settings {
+		rows = append(rows, []string{s.Key, s.Value})
+	}
+	b.WriteString("\nSettings\n")
+	b.WriteString(table.Render([]string{"Setting", "Value"}, rows))
+	return b.String(), nil
+}
+
+// sortValue turns a cell into a number when it is a count, a percentage or a byte size
+func sortValue(cell string) (float64, bool) {
+	s := strings.TrimSpace(strings.ToLower(cell))
+	s = strings.TrimSuffix(s, "%")
+	if v, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64); err == nil {
+		return v, true
+	}
+	units := []struct {
+		suffix string
+		factor float64
+	}{{"tb", 1 << 40}, {"gb", 1 << 30}, {"mb", 1 << 20}, {"kb", 1 << 10}, {"ms", 1}, {"b", 1}, {"s", 1000}}
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			if v, err := strconv.ParseFloat(strings.TrimSuffix(s, u.suffix), 64); err == nil {
+				return v * u.factor, true
+			}
+		}
+	}
+	return 0, false
+}
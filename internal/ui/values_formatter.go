@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mertbahardogan/escope/internal/constants"
+	"github.com/mertbahardogan/escope/internal/models"
+	"github.com/mertbahardogan/escope/internal/ui/components"
+	"github.com/mertbahardogan/escope/internal/util"
+)
+
+type ValuesFormatter struct {
+	table *components.Table
+	chart *components.BarChart
+}
+
+func NewValuesFormatter() *ValuesFormatter {
+	return &ValuesFormatter{table: components.NewTable(), chart: components.NewBarChart(constants.ValuesBarWidth)}
+}
+
+// FormatFieldValues renders top terms as a table and histograms as a bar chart
+func (f *ValuesFormatter) FormatFieldValues(values *models.FieldValues) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\nIndex: %s  Field: %s (%s)\n\n", values.Index, values.Field, values.Type))
+	if len(values.Buckets) == 0 {
+		b.WriteString("No values found\n")
+		return b.String()
+	}
+
+	if values.Kind == models.ValuesKindTerms {
+		rows := make([][]string, 0, len(values.Buckets))
+		for _, v := range values.Buckets {
+			rows = append(rows, []string{v.Key, util.FormatDocsCount(v.Count), fmt.Sprintf("%.1f%%", v.Percent)})
+		}
+		b.WriteString(f.table.Render([]string{"Value", "Documents", "% of Total"}, rows))
+		b.WriteString(fmt.Sprintf("Total: %s documents, %s in other values\n", util.FormatDocsCount(values.Total), util.FormatDocsCount(values.Other)))
+		return b.String()
+	}
+
+	labels := make([]string, 0, len(values.Buckets))
+	counts := make([]int64, 0, len(values.Buckets))
+	notes := make([]string, 0, len(values.Buckets))
+	for _, v := range values.Buckets {
+		labels = append(labels, v.Key)
+		counts = append(counts, v.Count)
+		notes = append(notes, fmt.Sprintf("%d (%.1f%%)", v.Count, v.Percent))
+	}
+	b.WriteString(f.chart.Render(labels, counts, notes))
+	b.WriteString(fmt.Sprintf("\nTotal: %s documents, %d buckets of %s\n", util.FormatDocsCount(values.Total), len(values.Buckets), values.Interval))
+	return b.String()
+}
@@ -73,6 +73,32 @@ func FormatDocsCount(count int64) string {
 	return str
 }
 
+func FormatRate(rate float64) string {
+	if rate >= constants.ThousandDivisor {
+		return fmt.Sprintf(constants.RateFormatK, rate/constants.ThousandDivisor)
+	} else if rate >= 1 {
+		return fmt.Sprintf(constants.RateFormat, rate)
+	}
+	return fmt.Sprintf(constants.RateFormat2, rate)
+}
+
+// FormatRateOrDash formats a rate, or a dash when it could not be measured (negative)
+func FormatRateOrDash(rate float64) string {
+	if rate < 0 {
+		return constants.DashString
+	}
+	return FormatRate(rate)
+}
+
+// FormatMillisOrDash formats a duration in milliseconds, or a dash when it could not be
+// measured (negative)
+func FormatMillisOrDash(ms float64) string {
+	if ms < 0 {
+		return constants.DashString
+	}
+	return fmt.Sprintf(constants.TimeFormatMS, ms)
+}
+
 func GetStringField(data map[string]interface{}, key string) string {
 	if value, ok := data[key]; ok {
 		if str, ok := value.(string); ok {
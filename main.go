@@ -10,11 +10,13 @@ import (
 	_ "github.com/mertbahardogan/escope/cmd/index"
 	_ "github.com/mertbahardogan/escope/cmd/lucene"
 	_ "github.com/mertbahardogan/escope/cmd/node"
+	_ "github.com/mertbahardogan/escope/cmd/search"
 	_ "github.com/mertbahardogan/escope/cmd/segments"
 	_ "github.com/mertbahardogan/escope/cmd/shard"
 	_ "github.com/mertbahardogan/escope/cmd/sort"
 	_ "github.com/mertbahardogan/escope/cmd/system"
 	_ "github.com/mertbahardogan/escope/cmd/termvectors"
+	_ "github.com/mertbahardogan/escope/cmd/ui"
 	_ "github.com/mertbahardogan/escope/cmd/upgrade"
 )
 
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mertbahardogan/escope/cmd/core"
+	"github.com/spf13/cobra"
+)
+
+// TestCommandTreeHelp runs --help on every command. pflag panics when a command redefines a
+// shorthand of the root persistent flags, which otherwise only shows up when the command runs.
+func TestCommandTreeHelp(t *testing.T) {
+	core.RootCmd.SetOut(io.Discard)
+	core.RootCmd.SetErr(io.Discard)
+	defer core.RootCmd.SetOut(nil)
+	defer core.RootCmd.SetErr(nil)
+
+	var walk func(cmd *cobra.Command, path []string)
+	walk = func(cmd *cobra.Command, path []string) {
+		for _, sub := range cmd.Commands() {
+			subPath := append(append([]string{}, path...), sub.Name())
+			t.Run(strings.Join(subPath, " "), func(t *testing.T) {
+				if err := runHelp(subPath); err != nil {
+					t.Fatal(err)
+				}
+			})
+			walk(sub, subPath)
+		}
+	}
+	walk(core.RootCmd, nil)
+}
+
+func runHelp(path []string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	core.RootCmd.SetArgs(append(append([]string{}, path...), "--help"))
+	return core.RootCmd.Execute()
+}